@@ -16,6 +16,7 @@ require (
 	github.com/mitchellh/reflectwalk v1.0.1 // indirect
 	github.com/vmware/vra-sdk-go v0.1.0
 	github.com/zclconf/go-cty v1.1.0 // indirect
+	gopkg.in/yaml.v2 v2.2.2
 )
 
 replace git.apache.org/thrift.git => github.com/apache/thrift v0.0.0-20180902110319-2566ecd5d999
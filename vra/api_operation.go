@@ -0,0 +1,78 @@
+package vra
+
+import (
+	"errors"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/go-openapi/runtime"
+	"github.com/go-openapi/strfmt"
+	"github.com/vmware/vra-sdk-go/pkg/client"
+	"github.com/vmware/vra-sdk-go/pkg/models"
+)
+
+// apiOperation is an API call the SDK does not provide, sent through the SDK transport
+type apiOperation struct {
+	ID          string
+	Method      string
+	PathPattern string
+	PathParams  map[string]string
+	QueryParams map[string]string
+	Body        interface{}
+}
+
+// submitAPIOperation sends the operation and decodes a successful response into result
+func submitAPIOperation(apiClient *client.MulticloudIaaS, operation apiOperation, result interface{}) error {
+	_, err := apiClient.Transport.Submit(&runtime.ClientOperation{
+		ID:                 operation.ID,
+		Method:             operation.Method,
+		PathPattern:        operation.PathPattern,
+		ProducesMediaTypes: []string{"app/json", "application/json"},
+		ConsumesMediaTypes: []string{"application/json"},
+		Schemes:            []string{"https"},
+		Params: runtime.ClientRequestWriterFunc(func(r runtime.ClientRequest, reg strfmt.Registry) error {
+			if err := r.SetTimeout(30 * time.Second); err != nil {
+				return err
+			}
+			for name, value := range operation.PathParams {
+				if err := r.SetPathParam(name, value); err != nil {
+					return err
+				}
+			}
+			for name, value := range operation.QueryParams {
+				if err := r.SetQueryParam(name, value); err != nil {
+					return err
+				}
+			}
+			if operation.Body != nil {
+				return r.SetBodyParam(operation.Body)
+			}
+			return nil
+		}),
+		Reader: runtime.ClientResponseReaderFunc(func(response runtime.ClientResponse, consumer runtime.Consumer) (interface{}, error) {
+			switch response.Code() {
+			case http.StatusOK, http.StatusAccepted:
+				if err := consumer.Consume(response.Body(), result); err != nil && err != io.EOF {
+					return nil, err
+				}
+				return result, nil
+			default:
+				return nil, runtime.NewAPIError("unknown error", response, response.Code())
+			}
+		}),
+	})
+	return err
+}
+
+// submitAPIRequest sends an operation answered with a request tracker and returns its ID
+func submitAPIRequest(apiClient *client.MulticloudIaaS, operation apiOperation) (string, error) {
+	requestTracker := new(models.RequestTracker)
+	if err := submitAPIOperation(apiClient, operation, requestTracker); err != nil {
+		return "", err
+	}
+	if requestTracker.ID == nil {
+		return "", errors.New("no request tracker returned")
+	}
+	return *requestTracker.ID, nil
+}
@@ -0,0 +1,103 @@
+package vra
+
+import (
+	"net/http"
+	"net/http/httptest"
+	neturl "net/url"
+	"testing"
+
+	httptransport "github.com/go-openapi/runtime/client"
+	"github.com/go-openapi/strfmt"
+	"github.com/vmware/vra-sdk-go/pkg/client"
+	"github.com/vmware/vra-sdk-go/pkg/models"
+)
+
+// testServerAPIClient returns an API client sending its requests to a test TLS server
+func testServerAPIClient(server *httptest.Server) *client.MulticloudIaaS {
+	serverURL, _ := neturl.Parse(server.URL)
+	transport := httptransport.New(serverURL.Host, "", []string{"https"})
+	transport.Transport = server.Client().Transport
+	return client.New(transport, strfmt.Default)
+}
+
+func TestSubmitAPIOperation(t *testing.T) {
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch r.URL.Path {
+		case "/iaas/api/networks/n-1":
+			if r.URL.Query().Get("apiVersion") != "2019-01-15" {
+				t.Errorf("expected the query parameters to be sent, got %s", r.URL)
+			}
+			w.Write([]byte(`{"id":"n-1","name":"my-network"}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+			w.Write([]byte(`{"message":"not found"}`))
+		}
+	}))
+	defer server.Close()
+
+	apiClient := testServerAPIClient(server)
+
+	network := new(models.Network)
+	err := submitAPIOperation(apiClient, apiOperation{
+		ID:          "getNetwork",
+		Method:      http.MethodGet,
+		PathPattern: "/iaas/api/networks/{id}",
+		PathParams:  map[string]string{"id": "n-1"},
+		QueryParams: map[string]string{"apiVersion": "2019-01-15"},
+	}, network)
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+	if network.Name != "my-network" {
+		t.Fatalf("expected the response to be decoded, got %#v", network)
+	}
+
+	err = submitAPIOperation(apiClient, apiOperation{
+		ID:          "getNetwork",
+		Method:      http.MethodGet,
+		PathPattern: "/iaas/api/networks/{id}",
+		PathParams:  map[string]string{"id": "n-2"},
+	}, new(models.Network))
+	if !isNotFound(err) {
+		t.Fatalf("expected a not found API error, got %v", err)
+	}
+}
+
+func TestSubmitAPIRequest(t *testing.T) {
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusAccepted)
+		if r.URL.Path == "/iaas/api/block-devices/bd-1" {
+			w.Write([]byte(`{"id":"r-1","status":"INPROGRESS"}`))
+			return
+		}
+		w.Write([]byte(`{}`))
+	}))
+	defer server.Close()
+
+	apiClient := testServerAPIClient(server)
+
+	requestID, err := submitAPIRequest(apiClient, apiOperation{
+		ID:          "deleteBlockDevice",
+		Method:      http.MethodDelete,
+		PathPattern: "/iaas/api/block-devices/{id}",
+		PathParams:  map[string]string{"id": "bd-1"},
+	})
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+	if requestID != "r-1" {
+		t.Fatalf("expected request tracker r-1, got %s", requestID)
+	}
+
+	_, err = submitAPIRequest(apiClient, apiOperation{
+		ID:          "deleteBlockDevice",
+		Method:      http.MethodDelete,
+		PathPattern: "/iaas/api/block-devices/{id}",
+		PathParams:  map[string]string{"id": "bd-2"},
+	})
+	if err == nil {
+		t.Fatal("expected an error when no request tracker is returned")
+	}
+}
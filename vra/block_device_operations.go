@@ -0,0 +1,130 @@
+package vra
+
+import (
+	"fmt"
+	"log"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/vmware/vra-sdk-go/pkg/client"
+	"github.com/vmware/vra-sdk-go/pkg/models"
+)
+
+// blockDeviceUpdateSpecification is the body of the block device update, which the SDK does not provide
+type blockDeviceUpdateSpecification struct {
+	Name             string            `json:"name"`
+	Description      string            `json:"description"`
+	Tags             []*models.Tag     `json:"tags"`
+	CustomProperties map[string]string `json:"customProperties,omitempty"`
+}
+
+// updateBlockDevice updates the name, description, tags and custom properties of a block device
+func updateBlockDevice(apiClient *client.MulticloudIaaS, id string, spec blockDeviceUpdateSpecification) error {
+	log.Printf("[DEBUG] update block device %s: %#v", id, spec)
+	return submitAPIOperation(apiClient, apiOperation{
+		ID:          "updateBlockDevice",
+		Method:      http.MethodPatch,
+		PathPattern: "/iaas/api/block-devices/{id}",
+		PathParams:  map[string]string{"id": id},
+		Body:        &spec,
+	}, new(models.BlockDevice))
+}
+
+// resizeBlockDevice grows a block device to the given capacity and waits on the request tracker
+func resizeBlockDevice(apiClient *client.MulticloudIaaS, id string, capacityInGB int, timeout time.Duration) error {
+	requestID, err := submitBlockDeviceResize(apiClient, id, capacityInGB)
+	if err != nil {
+		return fmt.Errorf("error resizing block device %s to %d GB: %v", id, capacityInGB, err)
+	}
+
+	_, err = waitForRequestTracker(apiClient, requestID, timeout)
+	return err
+}
+
+// submitBlockDeviceResize sends the resize request and returns its request tracker ID
+func submitBlockDeviceResize(apiClient *client.MulticloudIaaS, id string, capacityInGB int) (string, error) {
+	log.Printf("[DEBUG] resizing block device %s to %d GB", id, capacityInGB)
+	return submitAPIRequest(apiClient, apiOperation{
+		ID:          "resizeBlockDevice",
+		Method:      http.MethodPost,
+		PathPattern: "/iaas/api/block-devices/{id}",
+		PathParams:  map[string]string{"id": id},
+		QueryParams: map[string]string{"capacityInGB": strconv.Itoa(capacityInGB)},
+	})
+}
+
+// diskSnapshotSpecification is the body of the block device snapshot request
+type diskSnapshotSpecification struct {
+	Name        string `json:"name,omitempty"`
+	Description string `json:"description,omitempty"`
+}
+
+// createBlockDeviceSnapshot sends the snapshot request and returns its request tracker ID
+func createBlockDeviceSnapshot(apiClient *client.MulticloudIaaS, blockDeviceID string, spec diskSnapshotSpecification) (string, error) {
+	log.Printf("[DEBUG] create snapshot of block device %s: %#v", blockDeviceID, spec)
+	return submitAPIRequest(apiClient, apiOperation{
+		ID:          "createBlockDeviceSnapshot",
+		Method:      http.MethodPost,
+		PathPattern: "/iaas/api/block-devices/{id}/operations/snapshots",
+		PathParams:  map[string]string{"id": blockDeviceID},
+		Body:        &spec,
+	})
+}
+
+// getBlockDeviceSnapshots returns the snapshots of a block device
+func getBlockDeviceSnapshots(apiClient *client.MulticloudIaaS, blockDeviceID string) ([]*models.Snapshot, error) {
+	snapshots := make([]*models.Snapshot, 0)
+	err := submitAPIOperation(apiClient, apiOperation{
+		ID:          "getBlockDeviceSnapshots",
+		Method:      http.MethodGet,
+		PathPattern: "/iaas/api/block-devices/{id}/snapshots",
+		PathParams:  map[string]string{"id": blockDeviceID},
+	}, &snapshots)
+	return snapshots, err
+}
+
+// getBlockDeviceSnapshot returns the given snapshot of a block device, nil when either does not exist
+func getBlockDeviceSnapshot(apiClient *client.MulticloudIaaS, blockDeviceID, snapshotID string) (*models.Snapshot, error) {
+	snapshot := new(models.Snapshot)
+	err := submitAPIOperation(apiClient, apiOperation{
+		ID:          "getBlockDeviceSnapshot",
+		Method:      http.MethodGet,
+		PathPattern: "/iaas/api/block-devices/{id}/snapshots/{id1}",
+		PathParams:  map[string]string{"id": blockDeviceID, "id1": snapshotID},
+	}, snapshot)
+	if err != nil {
+		if isNotFound(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return snapshot, nil
+}
+
+// deleteBlockDeviceSnapshot sends the snapshot deletion request and returns its request tracker ID
+func deleteBlockDeviceSnapshot(apiClient *client.MulticloudIaaS, blockDeviceID, snapshotID string) (string, error) {
+	return submitAPIRequest(apiClient, apiOperation{
+		ID:          "deleteBlockDeviceSnapshot",
+		Method:      http.MethodDelete,
+		PathPattern: "/iaas/api/block-devices/{id}/snapshots/{id1}",
+		PathParams:  map[string]string{"id": blockDeviceID, "id1": snapshotID},
+	})
+}
+
+// customizeDiffBlockDevice rejects at plan time shrinking an existing block device,
+// which vRA cannot apply
+func customizeDiffBlockDevice(d *schema.ResourceDiff, m interface{}) error {
+	if d.Id() == "" {
+		return nil
+	}
+
+	if d.HasChange("capacity_in_gb") && d.NewValueKnown("capacity_in_gb") {
+		old, new := d.GetChange("capacity_in_gb")
+		if new.(int) < old.(int) {
+			return fmt.Errorf("capacity_in_gb of block device %s cannot be decreased from %d to %d GB", d.Id(), old.(int), new.(int))
+		}
+	}
+	return nil
+}
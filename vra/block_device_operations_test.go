@@ -0,0 +1,159 @@
+package vra
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform/config"
+	"github.com/hashicorp/terraform/terraform"
+)
+
+func TestSubmitBlockDeviceResize(t *testing.T) {
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost || r.URL.Path != "/iaas/api/block-devices/bd-1" || r.URL.Query().Get("capacityInGB") != "20" {
+			t.Errorf("unexpected request %s %s", r.Method, r.URL)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusAccepted)
+		w.Write([]byte(`{"id":"r-1","status":"INPROGRESS"}`))
+	}))
+	defer server.Close()
+
+	requestID, err := submitBlockDeviceResize(testServerAPIClient(server), "bd-1", 20)
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+	if requestID != "r-1" {
+		t.Fatalf("expected request tracker r-1, got %s", requestID)
+	}
+}
+
+func TestCustomizeDiffBlockDevice(t *testing.T) {
+	state := &terraform.InstanceState{
+		ID: "bd-1",
+		Attributes: map[string]string{
+			"id":             "bd-1",
+			"name":           "data",
+			"project_id":     "p-1",
+			"capacity_in_gb": "10",
+			"description":    "data disk",
+		},
+	}
+
+	cases := []struct {
+		name   string
+		config map[string]interface{}
+		err    string
+	}{
+		{"grow", map[string]interface{}{"capacity_in_gb": 20}, ""},
+		{"shrink", map[string]interface{}{"capacity_in_gb": 5}, "cannot be decreased"},
+		{"protect", map[string]interface{}{"capacity_in_gb": 10, "deletion_protection": true}, ""},
+		{"description", map[string]interface{}{"capacity_in_gb": 10, "description": "logs"}, ""},
+		{"project", map[string]interface{}{"capacity_in_gb": 10, "project_id": "p-2"}, ""},
+		{"custom properties", map[string]interface{}{"capacity_in_gb": 10, "custom_properties": map[string]interface{}{"app": "db"}}, ""},
+		{"name", map[string]interface{}{"capacity_in_gb": 10, "name": "logs"}, ""},
+	}
+	for _, c := range cases {
+		configMap := map[string]interface{}{
+			"name":        "data",
+			"project_id":  "p-1",
+			"description": "data disk",
+		}
+		for k, v := range c.config {
+			configMap[k] = v
+		}
+		raw, err := config.NewRawConfig(configMap)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		diff, err := resourceBlockDevice().Diff(state, terraform.NewResourceConfig(raw), nil)
+		if c.name == "project" && (diff == nil || !diff.RequiresNew()) {
+			t.Errorf("%s: expected the block device to be replaced, got %v", c.name, diff)
+		}
+		if (c.name == "custom properties" || c.name == "name") && (diff == nil || diff.RequiresNew()) {
+			t.Errorf("%s: expected the block device to be updated in place, got %v", c.name, diff)
+		}
+		if c.err == "" && err != nil {
+			t.Errorf("%s: unexpected error %v", c.name, err)
+		}
+		if c.err != "" && (err == nil || !strings.Contains(err.Error(), c.err)) {
+			t.Errorf("%s: expected an error containing %q, got %v", c.name, c.err, err)
+		}
+	}
+}
+
+func TestUpdateBlockDevice(t *testing.T) {
+	var body map[string]interface{}
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPatch || r.URL.Path != "/iaas/api/block-devices/bd-1" {
+			t.Errorf("unexpected request %s %s", r.Method, r.URL)
+		}
+		json.NewDecoder(r.Body).Decode(&body)
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"id":"bd-1","name":"data"}`))
+	}))
+	defer server.Close()
+
+	err := updateBlockDevice(testServerAPIClient(server), "bd-1", blockDeviceUpdateSpecification{
+		Name:             "logs",
+		CustomProperties: map[string]string{"app": "db"},
+		Description:      "logs",
+		Tags:             expandTags([]interface{}{map[string]interface{}{"key": "cost-center", "value": "42"}}),
+	})
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+
+	if body["name"] != "logs" || body["description"] != "logs" {
+		t.Fatalf("expected the name and description to be sent, got %v", body)
+	}
+	if customProperties, ok := body["customProperties"].(map[string]interface{}); !ok || customProperties["app"] != "db" {
+		t.Fatalf("expected the custom properties to be sent, got %v", body)
+	}
+	tags, ok := body["tags"].([]interface{})
+	if !ok || len(tags) != 1 || tags[0].(map[string]interface{})["key"] != "cost-center" {
+		t.Fatalf("expected the tags to be sent, got %v", body)
+	}
+}
+
+func TestGetBlockDeviceSnapshot(t *testing.T) {
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch r.URL.Path {
+		case "/iaas/api/block-devices/bd-1/snapshots/s-1":
+			w.Write([]byte(`{"id":"s-1","name":"before-upgrade"}`))
+		case "/iaas/api/block-devices/bd-1/snapshots":
+			w.Write([]byte(`[{"id":"s-1","name":"before-upgrade"},{"id":"s-2"}]`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	apiClient := testServerAPIClient(server)
+
+	snapshot, err := getBlockDeviceSnapshot(apiClient, "bd-1", "s-1")
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+	if snapshot == nil || snapshot.Name != "before-upgrade" {
+		t.Fatalf("expected snapshot before-upgrade, got %#v", snapshot)
+	}
+
+	snapshot, err = getBlockDeviceSnapshot(apiClient, "bd-1", "s-3")
+	if err != nil || snapshot != nil {
+		t.Fatalf("expected a missing snapshot to be nil, got %#v, %v", snapshot, err)
+	}
+
+	ids, err := getBlockDeviceSnapshotIDs(apiClient, "bd-1")
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+	if len(ids) != 2 || !ids["s-1"] || !ids["s-2"] {
+		t.Fatalf("expected snapshots s-1 and s-2, got %v", ids)
+	}
+}
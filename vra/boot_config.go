@@ -0,0 +1,264 @@
+package vra
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/vmware/vra-sdk-go/pkg/models"
+	yaml "gopkg.in/yaml.v2"
+
+	"github.com/hashicorp/terraform/helper/schema"
+)
+
+const cloudConfigHeader = "#cloud-config"
+
+// bootConfigHeaders are the first lines cloud-init and cloudbase-init recognise in
+// user data besides #cloud-config. Scripts start with a shebang and MIME multipart
+// with a header, #ps1 also covers #ps1_sysnative and #ps1_x86.
+var bootConfigHeaders = []string{
+	"#!",
+	"#include",
+	"#cloud-boothook",
+	"#cloud-config-archive",
+	"#part-handler",
+	"#upstart-job",
+	"## template: jinja",
+	"Content-Type: multipart/",
+	"MIME-Version:",
+	"#ps1",
+	"<powershell>",
+	"<script>",
+	"rem cmd",
+}
+
+// bootConfigSchema returns the schema to use for the boot_config property
+func bootConfigSchema() *schema.Schema {
+	return &schema.Schema{
+		Type:     schema.TypeSet,
+		Optional: true,
+		MaxItems: 1,
+		Elem: &schema.Resource{
+			Schema: map[string]*schema.Schema{
+				"content": {
+					Type:         schema.TypeString,
+					Optional:     true,
+					ValidateFunc: validateBootConfigContent,
+				},
+				"users": {
+					Type:     schema.TypeList,
+					Optional: true,
+					Elem: &schema.Resource{
+						Schema: map[string]*schema.Schema{
+							"name": {
+								Type:     schema.TypeString,
+								Required: true,
+							},
+							"groups": {
+								Type:     schema.TypeList,
+								Optional: true,
+								Elem:     &schema.Schema{Type: schema.TypeString},
+							},
+							"shell": {
+								Type:     schema.TypeString,
+								Optional: true,
+							},
+							"sudo": {
+								Type:     schema.TypeString,
+								Optional: true,
+							},
+							"ssh_authorized_keys": {
+								Type:     schema.TypeList,
+								Optional: true,
+								Elem:     &schema.Schema{Type: schema.TypeString},
+							},
+						},
+					},
+				},
+				"packages": {
+					Type:     schema.TypeList,
+					Optional: true,
+					Elem:     &schema.Schema{Type: schema.TypeString},
+				},
+				"runcmd": {
+					Type:     schema.TypeList,
+					Optional: true,
+					Elem:     &schema.Schema{Type: schema.TypeString},
+				},
+				"write_files": {
+					Type:     schema.TypeList,
+					Optional: true,
+					Elem: &schema.Resource{
+						Schema: map[string]*schema.Schema{
+							"path": {
+								Type:     schema.TypeString,
+								Required: true,
+							},
+							"content": {
+								Type:     schema.TypeString,
+								Required: true,
+							},
+							"owner": {
+								Type:     schema.TypeString,
+								Optional: true,
+							},
+							"permissions": {
+								Type:     schema.TypeString,
+								Optional: true,
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+// validateBootConfigContent checks that the content is #cloud-config YAML or user
+// data cloud-init or cloudbase-init recognise, so that mistakes show up in the plan
+// rather than once vRA has accepted the machine request. Leading blank lines are
+// ignored like they are by vRA.
+func validateBootConfigContent(v interface{}, k string) ([]string, []error) {
+	content := strings.TrimLeft(v.(string), " \t\r\n")
+	if content == "" {
+		return nil, nil
+	}
+
+	firstLine, _, _ := bufio.NewReader(strings.NewReader(content)).ReadLine()
+	header := strings.TrimSpace(string(firstLine))
+
+	if header == cloudConfigHeader {
+		var cloudConfig map[string]interface{}
+		if err := yaml.Unmarshal([]byte(content), &cloudConfig); err != nil {
+			return nil, []error{fmt.Errorf("%q is not valid cloud-config YAML: %v", k, err)}
+		}
+		return nil, nil
+	}
+
+	for _, prefix := range bootConfigHeaders {
+		if strings.HasPrefix(strings.ToLower(header), strings.ToLower(prefix)) {
+			return nil, nil
+		}
+	}
+	return nil, []error{fmt.Errorf("%q must start with %s, a script shebang, a MIME multipart header or a cloudbase-init header, got %q", k, cloudConfigHeader, header)}
+}
+
+// customizeDiffBootConfig checks at plan time that boot_config sets either content
+// or the structured blocks
+func customizeDiffBootConfig(d *schema.ResourceDiff, m interface{}) error {
+	configBootConfigs := d.Get("boot_config").(*schema.Set).List()
+	if len(configBootConfigs) == 0 || configBootConfigs[0] == nil {
+		return nil
+	}
+	return validateBootConfig(configBootConfigs[0].(map[string]interface{}))
+}
+
+func validateBootConfig(configBootConfig map[string]interface{}) error {
+	if configBootConfig["content"].(string) != "" && hasStructuredBootConfig(configBootConfig) {
+		return errors.New("boot_config content cannot be combined with users, packages, runcmd or write_files")
+	}
+	return nil
+}
+
+func hasStructuredBootConfig(configBootConfig map[string]interface{}) bool {
+	for _, key := range []string{"users", "packages", "runcmd", "write_files"} {
+		if v, ok := configBootConfig[key].([]interface{}); ok && len(v) > 0 {
+			return true
+		}
+	}
+	return false
+}
+
+func expandBootConfig(configBootConfigs []interface{}) (*models.MachineBootConfig, error) {
+	if len(configBootConfigs) == 0 || configBootConfigs[0] == nil {
+		return nil, nil
+	}
+
+	configBootConfig := configBootConfigs[0].(map[string]interface{})
+	if err := validateBootConfig(configBootConfig); err != nil {
+		return nil, err
+	}
+
+	content := configBootConfig["content"].(string)
+	if hasStructuredBootConfig(configBootConfig) {
+		rendered, err := renderCloudConfig(configBootConfig)
+		if err != nil {
+			return nil, err
+		}
+		content = rendered
+	}
+
+	return &models.MachineBootConfig{
+		Content: content,
+	}, nil
+}
+
+// renderCloudConfig renders the users, packages, runcmd and write_files blocks of
+// boot_config as #cloud-config content. A user named default keeps the default
+// user of the image.
+func renderCloudConfig(configBootConfig map[string]interface{}) (string, error) {
+	cloudConfig := make(map[string]interface{})
+
+	if v, ok := configBootConfig["users"].([]interface{}); ok && len(v) > 0 {
+		users := make([]interface{}, 0, len(v))
+		for _, configUser := range v {
+			userMap := configUser.(map[string]interface{})
+			user := map[string]interface{}{
+				"name": userMap["name"],
+			}
+			if groups := expandStringList(userMap["groups"].([]interface{})); len(groups) > 0 {
+				user["groups"] = strings.Join(groups, ", ")
+			}
+			if shell := userMap["shell"].(string); shell != "" {
+				user["shell"] = shell
+			}
+			if sudo := userMap["sudo"].(string); sudo != "" {
+				user["sudo"] = sudo
+			}
+			if keys := expandStringList(userMap["ssh_authorized_keys"].([]interface{})); len(keys) > 0 {
+				user["ssh_authorized_keys"] = keys
+			}
+
+			if len(user) == 1 && user["name"] == "default" {
+				users = append(users, "default")
+			} else {
+				users = append(users, user)
+			}
+		}
+		cloudConfig["users"] = users
+	}
+
+	if v, ok := configBootConfig["packages"].([]interface{}); ok && len(v) > 0 {
+		cloudConfig["packages"] = expandStringList(v)
+	}
+
+	if v, ok := configBootConfig["runcmd"].([]interface{}); ok && len(v) > 0 {
+		cloudConfig["runcmd"] = expandStringList(v)
+	}
+
+	if v, ok := configBootConfig["write_files"].([]interface{}); ok && len(v) > 0 {
+		files := make([]map[string]interface{}, 0, len(v))
+		for _, configFile := range v {
+			fileMap := configFile.(map[string]interface{})
+			file := map[string]interface{}{
+				"path":    fileMap["path"],
+				"content": fileMap["content"],
+			}
+			if owner := fileMap["owner"].(string); owner != "" {
+				file["owner"] = owner
+			}
+			if permissions := fileMap["permissions"].(string); permissions != "" {
+				file["permissions"] = permissions
+			}
+			files = append(files, file)
+		}
+		cloudConfig["write_files"] = files
+	}
+
+	out, err := yaml.Marshal(cloudConfig)
+	if err != nil {
+		return "", fmt.Errorf("error rendering boot_config as cloud-config: %v", err)
+	}
+	return cloudConfigHeader + "\n" + string(out), nil
+}
@@ -0,0 +1,113 @@
+package vra
+
+import (
+	"testing"
+)
+
+func TestValidateBootConfigContent(t *testing.T) {
+	valid := []string{
+		"",
+		"#cloud-config\npackages:\n  - nginx\n",
+		"#cloud-config\n",
+		"#!/bin/bash\necho hello\n",
+		"Content-Type: multipart/mixed; boundary=\"==BOUNDARY==\"\nMIME-Version: 1.0\n",
+		"#include\nhttps://example.com/user-data\n",
+		"\n\n#cloud-config\npackages:\n  - nginx\n",
+		"  \r\n#!/bin/sh\n",
+		"## template: jinja\n#cloud-config\nhostname: {{ v1.local_hostname }}\n",
+		"#ps1_sysnative\nRename-Computer web\n",
+		"#ps1_x86\nGet-Date\n",
+		"<powershell>\nGet-Date\n</powershell>\n",
+		"<script>\necho hello\n</script>\n",
+		"rem cmd\necho hello\n",
+	}
+	for _, content := range valid {
+		if _, errs := validateBootConfigContent(content, "content"); len(errs) > 0 {
+			t.Errorf("expected %q to be valid, got %v", content, errs)
+		}
+	}
+
+	invalid := []string{
+		"packages:\n  - nginx\n",
+		"#cloud-config\npackages: [nginx\n",
+		"#cloud-config\n- a list\n",
+		"\n\npackages:\n  - nginx\n",
+	}
+	for _, content := range invalid {
+		if _, errs := validateBootConfigContent(content, "content"); len(errs) == 0 {
+			t.Errorf("expected %q to be invalid", content)
+		}
+	}
+}
+
+func TestExpandBootConfig(t *testing.T) {
+	bootConfig, err := expandBootConfig([]interface{}{
+		map[string]interface{}{
+			"content":  "",
+			"packages": []interface{}{"nginx"},
+			"runcmd":   []interface{}{"systemctl start nginx"},
+			"users": []interface{}{
+				map[string]interface{}{
+					"name":                "default",
+					"groups":              []interface{}{},
+					"shell":               "",
+					"sudo":                "",
+					"ssh_authorized_keys": []interface{}{},
+				},
+				map[string]interface{}{
+					"name":                "deploy",
+					"groups":              []interface{}{"adm", "wheel"},
+					"shell":               "/bin/bash",
+					"sudo":                "ALL=(ALL) NOPASSWD:ALL",
+					"ssh_authorized_keys": []interface{}{"ssh-rsa AAAA"},
+				},
+			},
+			"write_files": []interface{}{
+				map[string]interface{}{
+					"path":        "/etc/motd",
+					"content":     "hello",
+					"owner":       "",
+					"permissions": "0644",
+				},
+			},
+		},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expected := `#cloud-config
+packages:
+- nginx
+runcmd:
+- systemctl start nginx
+users:
+- default
+- groups: adm, wheel
+  name: deploy
+  shell: /bin/bash
+  ssh_authorized_keys:
+  - ssh-rsa AAAA
+  sudo: ALL=(ALL) NOPASSWD:ALL
+write_files:
+- content: hello
+  path: /etc/motd
+  permissions: "0644"
+`
+	if bootConfig.Content != expected {
+		t.Fatalf("expected rendered content\n%s\ngot\n%s", expected, bootConfig.Content)
+	}
+	if _, errs := validateBootConfigContent(bootConfig.Content, "content"); len(errs) > 0 {
+		t.Fatalf("expected rendered content to be valid, got %v", errs)
+	}
+
+	_, err = expandBootConfig([]interface{}{
+		map[string]interface{}{
+			"content":  "#cloud-config\n",
+			"packages": []interface{}{"nginx"},
+		},
+	})
+	if err == nil {
+		t.Fatal("expected content combined with packages to fail")
+	}
+}
@@ -1,8 +1,12 @@
 package vra
 
 import (
+	"bytes"
+	"crypto/x509"
+	"encoding/json"
+	"errors"
 	"fmt"
-	"log"
+	"net/http"
 	neturl "net/url"
 	"os"
 	"strings"
@@ -14,43 +18,160 @@ import (
 	"github.com/vmware/vra-sdk-go/pkg/models"
 )
 
+// TLSOptions holds the TLS settings used to reach the vRA endpoint from provider config
+type TLSOptions struct {
+	Insecure       bool
+	CAFile         string
+	CAPEM          string
+	ClientCertFile string
+	ClientKeyFile  string
+}
+
+// transport returns an http transport verifying the server against the configured
+// CA bundle, and presenting the client certificate if one is configured
+func (o TLSOptions) transport() (http.RoundTripper, error) {
+	cfg, err := httptransport.TLSClientAuth(httptransport.TLSClientOptions{
+		InsecureSkipVerify: o.Insecure,
+		CA:                 o.CAFile,
+		Certificate:        o.ClientCertFile,
+		Key:                o.ClientKeyFile,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if o.CAPEM != "" {
+		if cfg.RootCAs == nil {
+			cfg.RootCAs = x509.NewCertPool()
+		}
+		if !cfg.RootCAs.AppendCertsFromPEM([]byte(o.CAPEM)) {
+			return nil, errors.New("tls client ca: ca_pem does not contain any PEM encoded certificate")
+		}
+	}
+
+	return &http.Transport{TLSClientConfig: cfg}, nil
+}
+
 // Client the VRA Client
 type Client struct {
-	url       string
-	apiClient *client.MulticloudIaaS
+	url          string
+	refreshToken string
+	username     string
+	password     string
+	domain       string
+	tlsOptions   TLSOptions
+	limiter      *requestLimiter
+	apiClient    *client.MulticloudIaaS
 }
 
 // NewClientFromRefreshToken configures and returns a VRA "Client" struct using "refresh_token" from provider config
-func NewClientFromRefreshToken(url, refreshToken string, insecure bool) (interface{}, error) {
-	token, err := getToken(url, refreshToken, insecure)
+func NewClientFromRefreshToken(url, refreshToken string, tlsOptions TLSOptions, retryOptions RetryOptions, limitOptions LimitOptions) (interface{}, error) {
+	c := &Client{url: url, refreshToken: refreshToken, tlsOptions: tlsOptions, limiter: newRequestLimiter(limitOptions)}
+	token, err := c.renewAccessToken()
 	if err != nil {
 		return "", err
 	}
-	apiClient, err := getAPIClient(url, token, insecure)
+	apiClient, err := getAPIClient(url, token, c.renewAccessToken, c.limiter, tlsOptions, retryOptions)
 	if err != nil {
 		return "", err
 	}
-	return &Client{url, apiClient}, nil
+	c.apiClient = apiClient
+	return c, nil
 }
 
 // NewClientFromAccessToken configures and returns a VRA "Client" struct using "access_token" from provider config
-func NewClientFromAccessToken(url, accessToken string, insecure bool) (interface{}, error) {
-	apiClient, err := getAPIClient(url, accessToken, insecure)
+func NewClientFromAccessToken(url, accessToken string, tlsOptions TLSOptions, retryOptions RetryOptions, limitOptions LimitOptions) (interface{}, error) {
+	c := &Client{url: url, tlsOptions: tlsOptions, limiter: newRequestLimiter(limitOptions)}
+	apiClient, err := getAPIClient(url, accessToken, nil, c.limiter, tlsOptions, retryOptions)
+	if err != nil {
+		return "", err
+	}
+	c.apiClient = apiClient
+	return c, nil
+}
+
+// NewClientFromPassword configures and returns a VRA "Client" struct using "username", "password" and "domain" from provider config
+func NewClientFromPassword(url, username, password, domain string, tlsOptions TLSOptions, retryOptions RetryOptions, limitOptions LimitOptions) (interface{}, error) {
+	c := &Client{url: url, username: username, password: password, domain: domain, tlsOptions: tlsOptions, limiter: newRequestLimiter(limitOptions)}
+	token, err := c.renewAccessToken()
+	if err != nil {
+		return "", err
+	}
+	apiClient, err := getAPIClient(url, token, c.renewAccessToken, c.limiter, tlsOptions, retryOptions)
+	if err != nil {
+		return "", err
+	}
+	c.apiClient = apiClient
+	return c, nil
+}
+
+// renewAccessToken exchanges the refresh token kept by the client for a new access token,
+// logging in again first when the client was configured with a username and password
+func (c *Client) renewAccessToken() (string, error) {
+	if c.username != "" {
+		refreshToken, err := getRefreshToken(c.url, c.username, c.password, c.domain, c.tlsOptions)
+		if err != nil {
+			return "", err
+		}
+		c.refreshToken = refreshToken
+	}
+	return getToken(c.url, c.refreshToken, c.tlsOptions)
+}
+
+// getRefreshToken logs in to the identity service of an on-prem vRA 8 appliance and returns a refresh token
+func getRefreshToken(url, username, password, domain string, tlsOptions TLSOptions) (string, error) {
+	body, err := json.Marshal(map[string]string{
+		"username": username,
+		"password": password,
+		"domain":   domain,
+	})
+	if err != nil {
+		return "", err
+	}
+
+	transport, err := tlsOptions.transport()
+	if err != nil {
+		return "", err
+	}
+
+	loginURL := strings.TrimSuffix(url, "/") + "/csp/gateway/am/api/login?access_token"
+	req, err := http.NewRequest(http.MethodPost, loginURL, bytes.NewReader(body))
+	if err != nil {
+		return "", err
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := (&http.Client{Transport: transport}).Do(req)
 	if err != nil {
 		return "", err
 	}
-	return &Client{url, apiClient}, nil
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("login as %s failed with status %s", username, resp.Status)
+	}
+
+	var loginResponse struct {
+		RefreshToken string `json:"refresh_token"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&loginResponse); err != nil {
+		return "", err
+	}
+
+	if loginResponse.RefreshToken == "" {
+		return "", fmt.Errorf("login as %s did not return a refresh token", username)
+	}
+
+	return loginResponse.RefreshToken, nil
 }
 
-func getToken(url, refreshToken string, insecure bool) (string, error) {
+func getToken(url, refreshToken string, tlsOptions TLSOptions) (string, error) {
 	parsedURL, err := neturl.Parse(url)
 	if err != nil {
 		return "", err
 	}
 	transport := httptransport.New(parsedURL.Host, "", nil)
-	newTransport, err := httptransport.TLSTransport(httptransport.TLSClientOptions{
-		InsecureSkipVerify: insecure,
-	})
+	newTransport, err := tlsOptions.transport()
 	if err != nil {
 		return "", err
 	}
@@ -64,39 +185,18 @@ func getToken(url, refreshToken string, insecure bool) (string, error) {
 		},
 	)
 	authTokenResponse, err := apiclient.Login.RetrieveAuthToken(params)
-	if err != nil || !strings.EqualFold(*authTokenResponse.Payload.TokenType, "bearer") {
+	if err != nil {
 		return "", err
 	}
 
-	return *authTokenResponse.Payload.Token, nil
-}
-
-// SwaggerLogger is the interface into the swagger logging facility which logs http traffic
-type SwaggerLogger struct{}
-
-// Printf is a swagger debug Printf
-func (SwaggerLogger) Printf(format string, args ...interface{}) {
-	s := fmt.Sprintf(format, args...)
-	// Handle and split mixed "\r\n" and "\n"
-	lines := strings.Split(strings.Replace(s, "\r\n", "\n", -1), "\n")
-
-	for _, l := range lines {
-		log.Printf("%s\n", l)
+	if !strings.EqualFold(*authTokenResponse.Payload.TokenType, "bearer") {
+		return "", fmt.Errorf("unexpected token type %s", *authTokenResponse.Payload.TokenType)
 	}
-}
 
-// Debugf is a swagger debug logger
-func (SwaggerLogger) Debugf(format string, args ...interface{}) {
-	s := fmt.Sprintf(format, args...)
-	// Handle and split mixed "\r\n" and "\n"
-	lines := strings.Split(strings.Replace(s, "\r\n", "\n", -1), "\n")
-
-	for _, l := range lines {
-		log.Printf("%s\n", l)
-	}
+	return *authTokenResponse.Payload.Token, nil
 }
 
-func getAPIClient(url string, token string, insecure bool) (*client.MulticloudIaaS, error) {
+func getAPIClient(url string, token string, refresh tokenRefreshFunc, limiter *requestLimiter, tlsOptions TLSOptions, retryOptions RetryOptions) (*client.MulticloudIaaS, error) {
 	debug := false
 	if os.Getenv("VRA_DEBUG") != "" {
 		debug = true
@@ -107,18 +207,14 @@ func getAPIClient(url string, token string, insecure bool) (*client.MulticloudIa
 		return nil, err
 	}
 	transport := httptransport.New(parsedURL.Host, "", nil)
-	transport.DefaultAuthentication = httptransport.APIKeyAuth("Authorization", "header", "Bearer "+token)
-	newTransport, err := httptransport.TLSTransport(httptransport.TLSClientOptions{
-		InsecureSkipVerify: insecure,
-	})
+	newTransport, err := tlsOptions.transport()
 	if err != nil {
 		return nil, err
 	}
-	transport.Transport = newTransport
 	if debug {
-		transport.SetDebug(debug)
-		transport.SetLogger(SwaggerLogger{})
+		newTransport = newDebugTransport(newTransport)
 	}
+	transport.Transport = newForceDeleteTransport(newRetryTransport(newLimitTransport(newBearerTokenTransport(newTransport, token, refresh), limiter), retryOptions))
 	apiclient := client.New(transport, strfmt.Default)
 	return apiclient, nil
 }
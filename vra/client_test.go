@@ -0,0 +1,82 @@
+package vra
+
+import (
+	"encoding/json"
+	"encoding/pem"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestTLSOptions_caPEM(t *testing.T) {
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	defer server.Close()
+
+	caPEM := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: server.Certificate().Raw})
+
+	transport, err := TLSOptions{CAPEM: string(caPEM)}.transport()
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+
+	req, _ := http.NewRequest("GET", server.URL, nil)
+	if _, err := transport.RoundTrip(req); err != nil {
+		t.Fatalf("expected the server certificate to be trusted, got: %s", err)
+	}
+
+	transport, err = TLSOptions{}.transport()
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+
+	if _, err := transport.RoundTrip(req); err == nil {
+		t.Fatal("expected the server certificate to be rejected without ca_pem")
+	}
+}
+
+func TestTLSOptions_invalidCAPEM(t *testing.T) {
+	if _, err := (TLSOptions{CAPEM: "not a certificate"}).transport(); err == nil {
+		t.Fatal("expected an error for an invalid ca_pem")
+	}
+}
+
+func TestGetRefreshToken(t *testing.T) {
+	var login map[string]string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost || r.URL.Path != "/csp/gateway/am/api/login" {
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+		}
+		if err := json.NewDecoder(r.Body).Decode(&login); err != nil {
+			t.Errorf("err: %s", err)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"refresh_token":"refresh"}`))
+	}))
+	defer server.Close()
+
+	refreshToken, err := getRefreshToken(server.URL+"/", "admin", "secret", "example.com", TLSOptions{})
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+	if refreshToken != "refresh" {
+		t.Errorf("expected the refresh token returned by the login, got %q", refreshToken)
+	}
+
+	expected := map[string]string{"username": "admin", "password": "secret", "domain": "example.com"}
+	for key, value := range expected {
+		if login[key] != value {
+			t.Errorf("expected the login to send %s %q, got %q", key, value, login[key])
+		}
+	}
+}
+
+func TestGetRefreshToken_failure(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusUnauthorized)
+	}))
+	defer server.Close()
+
+	if _, err := getRefreshToken(server.URL, "admin", "wrong", "", TLSOptions{}); err == nil {
+		t.Fatal("expected an error when the login is rejected")
+	}
+}
@@ -1,6 +1,10 @@
 package vra
 
 import (
+	"fmt"
+	"net/http"
+
+	"github.com/vmware/vra-sdk-go/pkg/client"
 	"github.com/vmware/vra-sdk-go/pkg/models"
 
 	"github.com/hashicorp/terraform/helper/schema"
@@ -43,7 +47,7 @@ func expandConstraints(configConstraints []interface{}) []*models.Constraint {
 	return constraints
 }
 
-/*
+// flattenConstraints flattens constraints as returned by vRA, skipping incomplete ones
 func flattenConstraints(constraints []*models.Constraint) []interface{} {
 	if len(constraints) == 0 {
 		return make([]interface{}, 0)
@@ -52,13 +56,51 @@ func flattenConstraints(constraints []*models.Constraint) []interface{} {
 	configConstraints := make([]interface{}, 0, len(constraints))
 
 	for _, constraint := range constraints {
+		if constraint == nil || constraint.Expression == nil {
+			continue
+		}
+
 		helper := make(map[string]interface{})
-		helper["mandatory"] = constraint.Mandatory
-		helper["expression"] = constraint.Expression
+		helper["mandatory"] = constraint.Mandatory != nil && *constraint.Mandatory
+		helper["expression"] = *constraint.Expression
 
 		configConstraints = append(configConstraints, helper)
 	}
 
 	return configConstraints
 }
-*/
+
+// resourceConstraints is the part of a machine or network response holding its
+// constraints, which the SDK models leave out
+type resourceConstraints struct {
+	Constraints []*models.Constraint `json:"constraints"`
+}
+
+// getResourceConstraints returns the constraints of the machine or network at the
+// given path, nil when vRA does not return any
+func getResourceConstraints(apiClient *client.MulticloudIaaS, pathPattern, id string) ([]*models.Constraint, error) {
+	result := new(resourceConstraints)
+	err := submitAPIOperation(apiClient, apiOperation{
+		ID:          "getResourceConstraints",
+		Method:      http.MethodGet,
+		PathPattern: pathPattern,
+		PathParams:  map[string]string{"id": id},
+	}, result)
+	return result.Constraints, err
+}
+
+// setConstraints reads back into state the constraints vRA returns for a machine or
+// network. The configured ones are kept when it returns none.
+func setConstraints(d *schema.ResourceData, apiClient *client.MulticloudIaaS, pathPattern, id string) error {
+	constraints, err := getResourceConstraints(apiClient, pathPattern, id)
+	if err != nil {
+		return fmt.Errorf("error reading constraints - error: %v", err)
+	}
+	if constraints == nil {
+		return nil
+	}
+	if err := d.Set("constraints", flattenConstraints(constraints)); err != nil {
+		return fmt.Errorf("error setting constraints - error: %v", err)
+	}
+	return nil
+}
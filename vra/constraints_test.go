@@ -0,0 +1,58 @@
+package vra
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+
+	"github.com/hashicorp/terraform/helper/schema"
+)
+
+func TestFlattenConstraints(t *testing.T) {
+	configConstraints := []interface{}{
+		map[string]interface{}{"mandatory": true, "expression": "env:dev"},
+		map[string]interface{}{"mandatory": false, "expression": "pci"},
+	}
+
+	if result := flattenConstraints(expandConstraints(configConstraints)); !reflect.DeepEqual(result, configConstraints) {
+		t.Fatalf("expected %v, got %v", configConstraints, result)
+	}
+}
+
+func TestSetConstraints(t *testing.T) {
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch r.URL.Path {
+		case "/iaas/api/machines/m-1":
+			w.Write([]byte(`{"id":"m-1","constraints":[{"mandatory":true,"expression":"env:prod"}]}`))
+		case "/iaas/api/machines/m-2":
+			w.Write([]byte(`{"id":"m-2"}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	apiClient := testServerAPIClient(server)
+	configured := map[string]interface{}{
+		"constraints": []interface{}{map[string]interface{}{"mandatory": false, "expression": "env:dev"}},
+	}
+
+	d := schema.TestResourceDataRaw(t, resourceMachine().Schema, configured)
+	if err := setConstraints(d, apiClient, "/iaas/api/machines/{id}", "m-1"); err != nil {
+		t.Fatalf("err: %s", err)
+	}
+	expected := []interface{}{map[string]interface{}{"mandatory": true, "expression": "env:prod"}}
+	if constraints := d.Get("constraints").(*schema.Set).List(); !reflect.DeepEqual(constraints, expected) {
+		t.Fatalf("expected the constraints vRA returns, got %v", constraints)
+	}
+
+	d = schema.TestResourceDataRaw(t, resourceMachine().Schema, configured)
+	if err := setConstraints(d, apiClient, "/iaas/api/machines/{id}", "m-2"); err != nil {
+		t.Fatalf("err: %s", err)
+	}
+	if constraints := d.Get("constraints").(*schema.Set).List(); !reflect.DeepEqual(constraints, configured["constraints"]) {
+		t.Fatalf("expected the configured constraints to be kept, got %v", constraints)
+	}
+}
@@ -1,5 +1,11 @@
 package vra
 
+import (
+	"strings"
+
+	"github.com/hashicorp/terraform/helper/schema"
+)
+
 func expandCustomProperties(configCustomProperties map[string]interface{}) map[string]string {
 	customProperties := make(map[string]string)
 
@@ -9,3 +15,43 @@ func expandCustomProperties(configCustomProperties map[string]interface{}) map[s
 
 	return customProperties
 }
+
+// suppressServerCustomProperties is a DiffSuppressFunc comparing only the custom
+// properties set in the configuration, ignoring the ones vRA adds to the resource.
+// A key only known to the state is taken as added by vRA, so a property removed
+// from the configuration is left on the resource.
+func suppressServerCustomProperties(k, old, new string, d *schema.ResourceData) bool {
+	// The count changes with every key vRA adds, the keys themselves carry the changes
+	if strings.HasSuffix(k, ".%") {
+		return true
+	}
+
+	return old != "" && new == ""
+}
+
+// flattenManagedCustomProperties returns the custom properties of the resource whose
+// keys are managed by Terraform, the ones vRA adds are left out of state
+func flattenManagedCustomProperties(customProperties map[string]string, managed map[string]interface{}) map[string]string {
+	managedCustomProperties := make(map[string]string)
+	for key := range managed {
+		if value, ok := customProperties[key]; ok {
+			managedCustomProperties[key] = value
+		}
+	}
+	return managedCustomProperties
+}
+
+// mergeCustomProperties applies a change of the managed custom properties to the ones
+// of the resource, keeping the properties vRA added
+func mergeCustomProperties(customProperties map[string]string, oldManaged, newManaged map[string]interface{}) map[string]string {
+	merged := make(map[string]string)
+	for key, value := range customProperties {
+		if _, ok := oldManaged[key]; !ok {
+			merged[key] = value
+		}
+	}
+	for key, value := range newManaged {
+		merged[key] = value.(string)
+	}
+	return merged
+}
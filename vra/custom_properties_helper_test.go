@@ -0,0 +1,93 @@
+package vra
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform/config"
+	"github.com/hashicorp/terraform/terraform"
+)
+
+func TestResourceMachineCustomPropertiesDiff(t *testing.T) {
+	// vcUuid was added by vRA, or read back by a previous version of the provider
+	state := &terraform.InstanceState{
+		ID: "m-1",
+		Attributes: map[string]string{
+			"id":                       "m-1",
+			"name":                     "my-machine",
+			"project_id":               "p-1",
+			"flavor":                   "small",
+			"image":                    "ubuntu",
+			"nics.#":                   "0",
+			"custom_properties.%":      "3",
+			"custom_properties.app":    "db",
+			"custom_properties.env":    "prod",
+			"custom_properties.vcUuid": "5030c4b0",
+		},
+	}
+
+	cases := []struct {
+		name             string
+		customProperties map[string]interface{}
+		requiresNew      bool
+	}{
+		{"unchanged", map[string]interface{}{"app": "db", "env": "prod"}, false},
+		{"not configured", nil, false},
+		{"removed", map[string]interface{}{"app": "db"}, false},
+		{"changed", map[string]interface{}{"app": "web", "env": "prod"}, true},
+		{"added", map[string]interface{}{"app": "db", "env": "prod", "owner": "ops"}, true},
+	}
+	for _, c := range cases {
+		configMap := map[string]interface{}{
+			"name":       "my-machine",
+			"project_id": "p-1",
+			"flavor":     "small",
+			"image":      "ubuntu",
+		}
+		if c.customProperties != nil {
+			configMap["custom_properties"] = c.customProperties
+		}
+		raw, err := config.NewRawConfig(configMap)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		diff, err := resourceMachine().Diff(state, terraform.NewResourceConfig(raw), nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if c.requiresNew && (diff == nil || !diff.RequiresNew()) {
+			t.Errorf("%s: expected the machine to be replaced, got %v", c.name, diff)
+		}
+		if !c.requiresNew && diff != nil {
+			for k := range diff.Attributes {
+				if strings.HasPrefix(k, "custom_properties") {
+					t.Errorf("%s: expected no change of the custom properties, got %s in %v", c.name, k, diff)
+				}
+			}
+		}
+	}
+}
+
+func TestMergeCustomProperties(t *testing.T) {
+	customProperties := map[string]string{"app": "web", "tier": "front", "vcUuid": "5030c4b0"}
+
+	managed := flattenManagedCustomProperties(customProperties, map[string]interface{}{"app": "web", "tier": "front", "gone": "x"})
+	if !reflect.DeepEqual(managed, map[string]string{"app": "web", "tier": "front"}) {
+		t.Fatalf("expected only the managed custom properties, got %v", managed)
+	}
+
+	merged := mergeCustomProperties(customProperties,
+		map[string]interface{}{"app": "web", "tier": "front"},
+		map[string]interface{}{"app": "api"},
+	)
+	if !reflect.DeepEqual(merged, map[string]string{"app": "api", "vcUuid": "5030c4b0"}) {
+		t.Fatalf("expected tier removed and vcUuid kept, got %v", merged)
+	}
+
+	merged = mergeCustomProperties(customProperties, map[string]interface{}{"app": "web", "tier": "front"}, map[string]interface{}{})
+	if !reflect.DeepEqual(merged, map[string]string{"vcUuid": "5030c4b0"}) {
+		t.Fatalf("expected the managed custom properties cleared, got %v", merged)
+	}
+}
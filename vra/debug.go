@@ -0,0 +1,164 @@
+package vra
+
+import (
+	"bytes"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"log"
+	"net/http"
+	"sort"
+	"strings"
+	"time"
+)
+
+// requestIDHeader carries the ID of each request so a failed apply can be correlated with the vRA server logs
+const requestIDHeader = "X-Request-Id"
+
+// redacted replaces the value of secrets in the debug logs
+const redacted = "********"
+
+// sensitiveHeaders are the http headers whose value is never logged
+var sensitiveHeaders = map[string]bool{
+	"Authorization": true,
+	"Cookie":        true,
+	"Set-Cookie":    true,
+}
+
+// sensitiveFields are the JSON fields, lower cased, whose value is never logged
+var sensitiveFields = map[string]bool{
+	"access_token":               true,
+	"apikey":                     true,
+	"clientapplicationsecretkey": true,
+	"password":                   true,
+	"privatekey":                 true,
+	"refresh_token":              true,
+	"refreshtoken":               true,
+	"secretaccesskey":            true,
+	"token":                      true,
+}
+
+// debugTransport logs every request and response with auth headers and known
+// secret JSON fields masked. Each line is tagged with the request ID, method
+// and path, and the response lines with the status and latency.
+type debugTransport struct {
+	transport http.RoundTripper
+}
+
+func newDebugTransport(transport http.RoundTripper) *debugTransport {
+	return &debugTransport{
+		transport: transport,
+	}
+}
+
+// RoundTrip implements http.RoundTripper
+func (t *debugTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	requestID := req.Header.Get(requestIDHeader)
+	if requestID == "" {
+		requestID = newRequestID()
+		r := new(http.Request)
+		*r = *req
+		r.Header = req.Header.Clone()
+		r.Header.Set(requestIDHeader, requestID)
+		req = r
+	}
+
+	tag := fmt.Sprintf("request_id=%s method=%s path=%s", requestID, req.Method, req.URL.Path)
+	log.Printf("[DEBUG] vra: %s request headers: %s", tag, redactHeaders(req.Header))
+	if req.GetBody != nil {
+		if body, err := req.GetBody(); err == nil {
+			b, _ := ioutil.ReadAll(body)
+			body.Close()
+			log.Printf("[DEBUG] vra: %s request body: %s", tag, redactBody(b))
+		}
+	}
+
+	start := time.Now()
+	resp, err := t.transport.RoundTrip(req)
+	latency := time.Since(start).Round(time.Millisecond)
+	if err != nil {
+		log.Printf("[DEBUG] vra: %s latency=%s error: %v", tag, latency, err)
+		return resp, err
+	}
+
+	tag = fmt.Sprintf("%s status=%d latency=%s", tag, resp.StatusCode, latency)
+	log.Printf("[DEBUG] vra: %s response headers: %s", tag, redactHeaders(resp.Header))
+
+	b, err := ioutil.ReadAll(resp.Body)
+	resp.Body.Close()
+	resp.Body = ioutil.NopCloser(bytes.NewReader(b))
+	if err != nil {
+		log.Printf("[DEBUG] vra: %s error reading the response body: %v", tag, err)
+		return resp, nil
+	}
+	log.Printf("[DEBUG] vra: %s response body: %s", tag, redactBody(b))
+
+	return resp, nil
+}
+
+// newRequestID returns a random request ID
+func newRequestID() string {
+	b := make([]byte, 8)
+	if _, err := rand.Read(b); err != nil {
+		return fmt.Sprintf("%x", time.Now().UnixNano())
+	}
+	return hex.EncodeToString(b)
+}
+
+// redactHeaders formats the headers on a single line with sensitive values masked
+func redactHeaders(header http.Header) string {
+	keys := make([]string, 0, len(header))
+	for k := range header {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	pairs := make([]string, 0, len(keys))
+	for _, k := range keys {
+		value := strings.Join(header[k], ", ")
+		if sensitiveHeaders[http.CanonicalHeaderKey(k)] {
+			value = redacted
+		}
+		pairs = append(pairs, fmt.Sprintf("%s=%q", k, value))
+	}
+	return strings.Join(pairs, " ")
+}
+
+// redactBody masks the known secret fields of a JSON body. Bodies that are not
+// JSON are only logged by size, they can't be inspected for secrets.
+func redactBody(b []byte) string {
+	if len(b) == 0 {
+		return "<empty>"
+	}
+
+	var v interface{}
+	if err := json.Unmarshal(b, &v); err != nil {
+		return fmt.Sprintf("<%d bytes>", len(b))
+	}
+
+	r, err := json.Marshal(redactValue(v))
+	if err != nil {
+		return fmt.Sprintf("<%d bytes>", len(b))
+	}
+	return string(r)
+}
+
+func redactValue(v interface{}) interface{} {
+	switch value := v.(type) {
+	case map[string]interface{}:
+		for k, field := range value {
+			if sensitiveFields[strings.ToLower(k)] {
+				value[k] = redacted
+				continue
+			}
+			value[k] = redactValue(field)
+		}
+	case []interface{}:
+		for i, item := range value {
+			value[i] = redactValue(item)
+		}
+	}
+	return v
+}
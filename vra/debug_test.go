@@ -0,0 +1,41 @@
+package vra
+
+import (
+	"net/http"
+	"strings"
+	"testing"
+)
+
+func TestRedactHeaders(t *testing.T) {
+	header := http.Header{}
+	header.Set("Authorization", "Bearer secret-token")
+	header.Set("Content-Type", "application/json")
+
+	s := redactHeaders(header)
+	if strings.Contains(s, "secret-token") {
+		t.Fatalf("expected the authorization header to be masked, got %s", s)
+	}
+	if !strings.Contains(s, `Content-Type="application/json"`) {
+		t.Fatalf("expected the content type to be logged, got %s", s)
+	}
+}
+
+func TestRedactBody(t *testing.T) {
+	body := `{"name":"my-account","password":"p4ss","cloudAccountProperties":{"apiKey":"k3y","dcId":"1"},"tags":[{"key":"env","value":"dev"}]}`
+
+	s := redactBody([]byte(body))
+	for _, secret := range []string{"p4ss", "k3y"} {
+		if strings.Contains(s, secret) {
+			t.Fatalf("expected %s to be masked, got %s", secret, s)
+		}
+	}
+	for _, value := range []string{"my-account", `"dcId":"1"`, `"key":"env"`} {
+		if !strings.Contains(s, value) {
+			t.Fatalf("expected %s to be logged, got %s", value, s)
+		}
+	}
+
+	if s := redactBody([]byte("password=p4ss")); strings.Contains(s, "p4ss") {
+		t.Fatalf("expected a body that is not JSON to be masked, got %s", s)
+	}
+}
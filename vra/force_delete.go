@@ -0,0 +1,58 @@
+package vra
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	"github.com/hashicorp/terraform/helper/schema"
+)
+
+type forceDeleteKey struct{}
+
+// withForceDelete marks the context of a delete request so that it is sent with
+// forceDelete=true. The SDK delete parameters have no forceDelete field, the
+// query parameter is added by the forceDeleteTransport.
+func withForceDelete(ctx context.Context) context.Context {
+	return context.WithValue(ctx, forceDeleteKey{}, true)
+}
+
+// forceDeleteTransport adds the forceDelete query parameter to DELETE requests
+// whose context was marked with withForceDelete
+type forceDeleteTransport struct {
+	transport http.RoundTripper
+}
+
+func newForceDeleteTransport(transport http.RoundTripper) *forceDeleteTransport {
+	return &forceDeleteTransport{
+		transport: transport,
+	}
+}
+
+// RoundTrip implements http.RoundTripper
+func (t *forceDeleteTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if force, _ := req.Context().Value(forceDeleteKey{}).(bool); force && req.Method == http.MethodDelete {
+		req = req.Clone(req.Context())
+		query := req.URL.Query()
+		query.Set("forceDelete", "true")
+		req.URL.RawQuery = query.Encode()
+	}
+	return t.transport.RoundTrip(req)
+}
+
+// deleteContext returns the context of the delete request of a resource with a
+// force_delete argument, nil to keep the SDK default
+func deleteContext(d *schema.ResourceData) context.Context {
+	if d.Get("force_delete").(bool) {
+		return withForceDelete(context.Background())
+	}
+	return nil
+}
+
+// checkDeletionProtection refuses to delete a resource whose deletion_protection is on
+func checkDeletionProtection(d *schema.ResourceData, resourceType string) error {
+	if d.Get("deletion_protection").(bool) {
+		return fmt.Errorf("%s %s has deletion_protection set, set it to false and apply before destroying it", resourceType, d.Id())
+	}
+	return nil
+}
@@ -0,0 +1,72 @@
+package vra
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/hashicorp/terraform/helper/schema"
+)
+
+func TestForceDeleteTransport(t *testing.T) {
+	var queries []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		queries = append(queries, r.Method+" "+r.URL.RawQuery)
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	transport := newForceDeleteTransport(http.DefaultTransport)
+	requests := []struct {
+		method string
+		ctx    context.Context
+	}{
+		{http.MethodDelete, context.Background()},
+		{http.MethodDelete, withForceDelete(context.Background())},
+		{http.MethodGet, withForceDelete(context.Background())},
+	}
+	for _, r := range requests {
+		req, _ := http.NewRequest(r.method, server.URL+"/iaas/api/machines/1?apiVersion=2019-01-15", nil)
+		resp, err := transport.RoundTrip(req.WithContext(r.ctx))
+		if err != nil {
+			t.Fatalf("err: %s", err)
+		}
+		resp.Body.Close()
+	}
+
+	expected := []string{
+		"DELETE apiVersion=2019-01-15",
+		"DELETE apiVersion=2019-01-15&forceDelete=true",
+		"GET apiVersion=2019-01-15",
+	}
+	for i := range expected {
+		if queries[i] != expected[i] {
+			t.Errorf("expected request %d to be %q, got %q", i, expected[i], queries[i])
+		}
+	}
+}
+
+func TestCheckDeletionProtection(t *testing.T) {
+	for _, protected := range []bool{false, true} {
+		d := schema.TestResourceDataRaw(t, resourceBlockDevice().Schema, map[string]interface{}{
+			"deletion_protection": protected,
+		})
+		d.SetId("disk-1")
+
+		err := checkDeletionProtection(d, "vra_block_device")
+		if protected && err == nil {
+			t.Fatal("expected a protected block device to refuse deletion")
+		}
+		if !protected && err != nil {
+			t.Fatalf("expected an unprotected block device to be deletable, got %v", err)
+		}
+	}
+
+	d := schema.TestResourceDataRaw(t, resourceBlockDevice().Schema, map[string]interface{}{
+		"force_delete": true,
+	})
+	if force, _ := deleteContext(d).Value(forceDeleteKey{}).(bool); !force {
+		t.Fatal("expected force_delete to mark the delete context")
+	}
+}
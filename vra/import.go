@@ -0,0 +1,99 @@
+package vra
+
+import (
+	"fmt"
+	"log"
+	"net/http"
+	"strconv"
+
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/vmware/vra-sdk-go/pkg/client"
+)
+
+// namedResource is the ID and name of an existing vRA object that can be imported
+type namedResource struct {
+	ID   string
+	Name string
+}
+
+// namedResourceListFunc lists the existing vRA objects of one resource type
+type namedResourceListFunc func(apiClient *client.MulticloudIaaS) ([]namedResource, error)
+
+// importByIDOrName returns an importer accepting either the ID or the unique name
+// of an existing object. The resource Read then populates the state.
+func importByIDOrName(list namedResourceListFunc) *schema.ResourceImporter {
+	return &schema.ResourceImporter{
+		State: func(d *schema.ResourceData, m interface{}) ([]*schema.ResourceData, error) {
+			resources, err := list(m.(*Client).apiClient)
+			if err != nil {
+				return nil, err
+			}
+
+			id, err := resolveImportID(d.Id(), resources)
+			if err != nil {
+				return nil, err
+			}
+
+			log.Printf("[DEBUG] importing %s as %s", d.Id(), id)
+			d.SetId(id)
+			return []*schema.ResourceData{d}, nil
+		},
+	}
+}
+
+// resolveImportID returns the ID of the object whose ID or name is the given value.
+// A value matching nothing is kept as an ID so that Read reports the missing object.
+func resolveImportID(value string, resources []namedResource) (string, error) {
+	ids := make([]string, 0)
+	for _, r := range resources {
+		if r.ID == value {
+			return r.ID, nil
+		}
+		if r.Name == value {
+			ids = append(ids, r.ID)
+		}
+	}
+
+	switch len(ids) {
+	case 0:
+		return value, nil
+	case 1:
+		return ids[0], nil
+	default:
+		return "", fmt.Errorf("%d objects are named %q, import one of them by ID: %v", len(ids), value, ids)
+	}
+}
+
+// importPageSize is the number of objects listed per request when resolving an import
+const importPageSize = 100
+
+// listPages calls list with the offset of every page of a vRA list operation, until
+// list returns an empty page or as many objects as the total it reports
+func listPages(list func(skip int) (int, int64, error)) error {
+	skip := 0
+	for {
+		listed, total, err := list(skip)
+		if err != nil {
+			return err
+		}
+
+		skip += listed
+		if listed == 0 || (total > 0 && int64(skip) >= total) || (total == 0 && listed < importPageSize) {
+			return nil
+		}
+	}
+}
+
+// listPage lists the page of objects starting at skip into result. The SDK list
+// operations take no $skip or $top, so the page is listed without the SDK.
+func listPage(apiClient *client.MulticloudIaaS, id, path string, skip int, result interface{}) error {
+	return submitAPIOperation(apiClient, apiOperation{
+		ID:          id,
+		Method:      http.MethodGet,
+		PathPattern: path,
+		QueryParams: map[string]string{
+			"$skip": strconv.Itoa(skip),
+			"$top":  strconv.Itoa(importPageSize),
+		},
+	}, result)
+}
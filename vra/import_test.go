@@ -0,0 +1,102 @@
+package vra
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+func TestResolveImportID(t *testing.T) {
+	resources := []namedResource{
+		{ID: "id-1", Name: "web"},
+		{ID: "id-2", Name: "db"},
+		{ID: "id-3", Name: "db"},
+	}
+
+	cases := []struct {
+		value string
+		id    string
+	}{
+		{"id-2", "id-2"},
+		{"web", "id-1"},
+		{"unknown", "unknown"},
+	}
+	for _, c := range cases {
+		id, err := resolveImportID(c.value, resources)
+		if err != nil {
+			t.Fatalf("unexpected error importing %q: %v", c.value, err)
+		}
+		if id != c.id {
+			t.Fatalf("expected %q to import %q, got %q", c.value, c.id, id)
+		}
+	}
+
+	if _, err := resolveImportID("db", resources); err == nil {
+		t.Fatal("expected an error when several objects have the imported name")
+	}
+}
+
+func TestImportListPages(t *testing.T) {
+	const zones = 250
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		skip, _ := strconv.Atoi(r.URL.Query().Get("$skip"))
+		top, _ := strconv.Atoi(r.URL.Query().Get("$top"))
+		if r.URL.Path != "/iaas/api/zones" || top != importPageSize {
+			t.Errorf("unexpected request %s %s", r.Method, r.URL)
+		}
+
+		content := make([]string, 0)
+		for i := skip; i < zones && i < skip+top; i++ {
+			content = append(content, fmt.Sprintf(`{"id":"zone-%d","name":"zone %d"}`, i, i))
+		}
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprintf(w, `{"content":[%s],"totalElements":%d}`, strings.Join(content, ","), zones)
+	}))
+	defer server.Close()
+
+	resources, err := resourceZoneImportList(testServerAPIClient(server))
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+	if len(resources) != zones {
+		t.Fatalf("expected %d zones, got %d", zones, len(resources))
+	}
+
+	id, err := resolveImportID("zone 242", resources)
+	if err != nil || id != "zone-242" {
+		t.Fatalf("expected a zone past the first page to be imported by name, got %q, %v", id, err)
+	}
+}
+
+func TestListPages(t *testing.T) {
+	cases := []struct {
+		name  string
+		pages []int
+		total int64
+		calls int
+	}{
+		{"total", []int{importPageSize, importPageSize, 20}, 2*importPageSize + 20, 3},
+		{"no total", []int{importPageSize, 20}, 0, 2},
+		{"empty page", []int{importPageSize, 0}, 0, 2},
+	}
+	for _, c := range cases {
+		calls := 0
+		err := listPages(func(skip int) (int, int64, error) {
+			if skip != calls*importPageSize {
+				t.Errorf("%s: expected page %d to start at %d, got %d", c.name, calls, calls*importPageSize, skip)
+			}
+			listed := c.pages[calls]
+			calls++
+			return listed, c.total, nil
+		})
+		if err != nil {
+			t.Fatalf("%s: err: %s", c.name, err)
+		}
+		if calls != c.calls {
+			t.Fatalf("%s: expected %d pages, got %d", c.name, c.calls, calls)
+		}
+	}
+}
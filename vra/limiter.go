@@ -0,0 +1,118 @@
+package vra
+
+import (
+	"io"
+	"net/http"
+	"sync"
+	"time"
+)
+
+// LimitOptions holds the client side throttling settings from provider config
+type LimitOptions struct {
+	MaxConcurrentRequests int
+	RequestsPerSecond     float64
+}
+
+// requestLimiter bounds the number of API requests in flight and paces them so
+// that no more than the configured number of requests per second are sent. A
+// single limiter is shared by every API call of a provider instance.
+type requestLimiter struct {
+	slots    chan struct{}
+	interval time.Duration
+
+	mu   sync.Mutex
+	next time.Time
+}
+
+func newRequestLimiter(options LimitOptions) *requestLimiter {
+	l := &requestLimiter{}
+	if options.MaxConcurrentRequests > 0 {
+		l.slots = make(chan struct{}, options.MaxConcurrentRequests)
+	}
+	if options.RequestsPerSecond > 0 {
+		l.interval = time.Duration(float64(time.Second) / options.RequestsPerSecond)
+	}
+	return l
+}
+
+// acquire waits for a free request slot and for the next pacing tick, it
+// returns the function releasing the slot
+func (l *requestLimiter) acquire(req *http.Request) (func(), error) {
+	release := func() {}
+	if l.slots != nil {
+		select {
+		case l.slots <- struct{}{}:
+		case <-req.Context().Done():
+			return nil, req.Context().Err()
+		}
+		var once sync.Once
+		release = func() { once.Do(func() { <-l.slots }) }
+	}
+
+	if l.interval > 0 {
+		if err := sleepContext(req.Context(), l.reserve()); err != nil {
+			release()
+			return nil, err
+		}
+	}
+
+	return release, nil
+}
+
+// reserve books the next send time and returns how long to wait for it
+func (l *requestLimiter) reserve() time.Duration {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	now := time.Now()
+	if l.next.Before(now) {
+		l.next = now
+	}
+	wait := l.next.Sub(now)
+	l.next = l.next.Add(l.interval)
+	return wait
+}
+
+// limitTransport sends every request through a shared requestLimiter
+type limitTransport struct {
+	transport http.RoundTripper
+	limiter   *requestLimiter
+}
+
+func newLimitTransport(transport http.RoundTripper, limiter *requestLimiter) *limitTransport {
+	return &limitTransport{
+		transport: transport,
+		limiter:   limiter,
+	}
+}
+
+// RoundTrip implements http.RoundTripper
+func (t *limitTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	release, err := t.limiter.acquire(req)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := t.transport.RoundTrip(req)
+	if err != nil {
+		release()
+		return nil, err
+	}
+
+	// The request holds its slot until the response has been read
+	resp.Body = &releaseOnClose{ReadCloser: resp.Body, release: release}
+	return resp, nil
+}
+
+// releaseOnClose calls release once the wrapped body is closed
+type releaseOnClose struct {
+	io.ReadCloser
+	release func()
+}
+
+// Close implements io.Closer
+func (b *releaseOnClose) Close() error {
+	err := b.ReadCloser.Close()
+	b.release()
+	return err
+}
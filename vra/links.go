@@ -1,6 +1,8 @@
 package vra
 
 import (
+	"strings"
+
 	"github.com/vmware/vra-sdk-go/pkg/models"
 
 	"github.com/hashicorp/terraform/helper/schema"
@@ -67,3 +69,31 @@ func getSelfLink(configLinks []interface{}) string {
 	return ""
 }
 */
+
+// linkID returns the ID of the object the given relation links to, the last
+// segment of links such as "/iaas/api/regions/<id>"
+func linkID(links map[string]models.Href, rel string) string {
+	if ids := linkIDs(links, rel); len(ids) > 0 {
+		return ids[0]
+	}
+	return ""
+}
+
+// linkIDs returns the IDs of the objects the given relation links to
+func linkIDs(links map[string]models.Href, rel string) []string {
+	link, ok := links[rel]
+	if !ok {
+		return []string{}
+	}
+
+	hrefs := link.Hrefs
+	if len(hrefs) == 0 && link.Href != "" {
+		hrefs = []string{link.Href}
+	}
+
+	ids := make([]string, 0, len(hrefs))
+	for _, href := range hrefs {
+		ids = append(ids, href[strings.LastIndex(href, "/")+1:])
+	}
+	return ids
+}
@@ -0,0 +1,189 @@
+package vra
+
+import (
+	"fmt"
+	"log"
+	"net/http"
+	"time"
+
+	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/vmware/vra-sdk-go/pkg/client"
+	"github.com/vmware/vra-sdk-go/pkg/client/disk"
+	"github.com/vmware/vra-sdk-go/pkg/models"
+)
+
+const (
+	machineDiskAttached = "attached"
+	machineDiskDetached = "detached"
+)
+
+// isBootDisk reports whether the block device is the boot disk created from the
+// machine image, which is listed with the machine disks but not managed in disks
+func isBootDisk(blockDevice *models.BlockDevice) bool {
+	return blockDevice.CustomProperties["bootOrder"] == "1"
+}
+
+// getMachineDisks returns the block devices attached to a machine, boot disk excluded
+func getMachineDisks(apiClient *client.MulticloudIaaS, machineID string) ([]*models.BlockDevice, error) {
+	ret, err := apiClient.Disk.GetMachineDisks(disk.NewGetMachineDisksParams().WithID(machineID))
+	if err != nil {
+		return nil, err
+	}
+
+	blockDevices := make([]*models.BlockDevice, 0, len(ret.Payload.Content))
+	for _, blockDevice := range ret.Payload.Content {
+		if blockDevice.ID == nil || isBootDisk(blockDevice) {
+			continue
+		}
+		blockDevices = append(blockDevices, blockDevice)
+	}
+	return blockDevices, nil
+}
+
+// getMachineDiskIDs returns the IDs of the block devices attached to a machine, boot disk excluded
+func getMachineDiskIDs(apiClient *client.MulticloudIaaS, machineID string) ([]string, error) {
+	blockDevices, err := getMachineDisks(apiClient, machineID)
+	if err != nil {
+		return nil, err
+	}
+
+	ids := make([]string, 0, len(blockDevices))
+	for _, blockDevice := range blockDevices {
+		ids = append(ids, *blockDevice.ID)
+	}
+	return ids, nil
+}
+
+// flattenMachineDisks returns the disks in state that are still attached to a machine.
+// Block devices attached outside of disks, by vra_block_device_attachment for instance,
+// are not read back. Name and description are only used when attaching, so the ones in
+// state are kept.
+func flattenMachineDisks(blockDeviceIDs []string, stateDisks []interface{}) []map[string]interface{} {
+	attached := make(map[string]bool)
+	for _, id := range blockDeviceIDs {
+		attached[id] = true
+	}
+
+	disks := make([]map[string]interface{}, 0, len(stateDisks))
+	for _, stateDisk := range stateDisks {
+		diskMap := stateDisk.(map[string]interface{})
+		if !attached[diskMap["block_device_id"].(string)] {
+			continue
+		}
+		disks = append(disks, map[string]interface{}{
+			"block_device_id": diskMap["block_device_id"],
+			"name":            diskMap["name"],
+			"description":     diskMap["description"],
+		})
+	}
+	return disks
+}
+
+// machineDiskChanges returns the block devices to detach from and attach to a
+// machine for the given change of disks, keyed by block device ID
+func machineDiskChanges(oldDisks, newDisks *schema.Set) ([]string, []*models.DiskAttachmentSpecification) {
+	oldIDs := make(map[string]bool)
+	for _, configDisk := range oldDisks.List() {
+		oldIDs[configDisk.(map[string]interface{})["block_device_id"].(string)] = true
+	}
+
+	newIDs := make(map[string]bool)
+	attach := make([]*models.DiskAttachmentSpecification, 0)
+	for _, spec := range expandDisks(newDisks.List()) {
+		newIDs[*spec.BlockDeviceID] = true
+		if !oldIDs[*spec.BlockDeviceID] {
+			attach = append(attach, spec)
+		}
+	}
+
+	detach := make([]string, 0)
+	for id := range oldIDs {
+		if !newIDs[id] {
+			detach = append(detach, id)
+		}
+	}
+	return detach, attach
+}
+
+// attachMachineDisk attaches a block device to a machine and waits until the machine lists it
+func attachMachineDisk(apiClient *client.MulticloudIaaS, machineID string, spec *models.DiskAttachmentSpecification, timeout time.Duration) error {
+	log.Printf("[DEBUG] attaching block device %s to machine %s", *spec.BlockDeviceID, machineID)
+	if _, err := apiClient.Disk.AttachMachineDisk(disk.NewAttachMachineDiskParams().WithID(machineID).WithBody(spec)); err != nil {
+		return fmt.Errorf("error attaching block device %s to machine %s: %v", *spec.BlockDeviceID, machineID, err)
+	}
+	return waitForMachineDisk(apiClient, machineID, *spec.BlockDeviceID, machineDiskAttached, timeout)
+}
+
+// diskAttachmentSpecification is the body of a disk attachment sent without the
+// SDK, whose model has no unit number to attach the block device at
+type diskAttachmentSpecification struct {
+	BlockDeviceID string `json:"blockDeviceId"`
+	Name          string `json:"name"`
+	UnitNumber    string `json:"unitNumber,omitempty"`
+}
+
+// attachBlockDevice attaches a block device to a machine at the unit number of the
+// specification, if any, and waits until the machine lists it
+func attachBlockDevice(apiClient *client.MulticloudIaaS, machineID string, spec diskAttachmentSpecification, timeout time.Duration) error {
+	if err := submitDiskAttachment(apiClient, machineID, spec); err != nil {
+		return fmt.Errorf("error attaching block device %s to machine %s: %v", spec.BlockDeviceID, machineID, err)
+	}
+	return waitForMachineDisk(apiClient, machineID, spec.BlockDeviceID, machineDiskAttached, timeout)
+}
+
+// submitDiskAttachment sends the disk attachment request
+func submitDiskAttachment(apiClient *client.MulticloudIaaS, machineID string, spec diskAttachmentSpecification) error {
+	log.Printf("[DEBUG] attaching block device %s to machine %s: %#v", spec.BlockDeviceID, machineID, spec)
+	return submitAPIOperation(apiClient, apiOperation{
+		ID:          "attachMachineDisk",
+		Method:      http.MethodPost,
+		PathPattern: "/iaas/api/machines/{id}/disks",
+		PathParams:  map[string]string{"id": machineID},
+		Body:        &spec,
+	}, new(models.RequestTracker))
+}
+
+// detachMachineDisk detaches a block device from a machine and waits until the machine no longer lists it
+func detachMachineDisk(apiClient *client.MulticloudIaaS, machineID, blockDeviceID string, timeout time.Duration) error {
+	log.Printf("[DEBUG] detaching block device %s from machine %s", blockDeviceID, machineID)
+	if _, err := apiClient.Disk.DeleteMachineDisk(disk.NewDeleteMachineDiskParams().WithID(machineID).WithId1(blockDeviceID)); err != nil {
+		return fmt.Errorf("error detaching block device %s from machine %s: %v", blockDeviceID, machineID, err)
+	}
+	return waitForMachineDisk(apiClient, machineID, blockDeviceID, machineDiskDetached, timeout)
+}
+
+// waitForMachineDisk waits for a block device to be attached to or detached from a
+// machine. The SDK models the attach and detach responses as block devices rather
+// than request trackers, so the machine disks are polled instead.
+func waitForMachineDisk(apiClient *client.MulticloudIaaS, machineID, blockDeviceID, target string, timeout time.Duration) error {
+	pending := machineDiskDetached
+	if target == machineDiskDetached {
+		pending = machineDiskAttached
+	}
+
+	stateChangeFunc := resource.StateChangeConf{
+		Delay:   5 * time.Second,
+		Pending: []string{pending},
+		Refresh: func() (interface{}, string, error) {
+			ids, err := getMachineDiskIDs(apiClient, machineID)
+			if err != nil {
+				return nil, "", err
+			}
+			for _, id := range ids {
+				if id == blockDeviceID {
+					return ids, machineDiskAttached, nil
+				}
+			}
+			return ids, machineDiskDetached, nil
+		},
+		Target:     []string{target},
+		Timeout:    timeout,
+		MinTimeout: 5 * time.Second,
+	}
+
+	if _, err := stateChangeFunc.WaitForState(); err != nil {
+		return fmt.Errorf("error waiting for block device %s to be %s on machine %s: %v", blockDeviceID, target, machineID, err)
+	}
+	return nil
+}
@@ -0,0 +1,120 @@
+package vra
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strconv"
+	"testing"
+
+	"github.com/hashicorp/terraform/config"
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/hashicorp/terraform/terraform"
+)
+
+func TestMachineDiskChanges(t *testing.T) {
+	diskSchema := resourceMachine().Schema["disks"]
+	oldDisks := schema.NewSet(schema.HashResource(diskSchema.Elem.(*schema.Resource)), []interface{}{
+		map[string]interface{}{"block_device_id": "bd-1", "name": "data", "description": ""},
+		map[string]interface{}{"block_device_id": "bd-2", "name": "", "description": ""},
+	})
+	newDisks := schema.NewSet(schema.HashResource(diskSchema.Elem.(*schema.Resource)), []interface{}{
+		map[string]interface{}{"block_device_id": "bd-1", "name": "renamed", "description": ""},
+		map[string]interface{}{"block_device_id": "bd-3", "name": "logs", "description": ""},
+	})
+
+	detach, attach := machineDiskChanges(oldDisks, newDisks)
+	if !reflect.DeepEqual(detach, []string{"bd-2"}) {
+		t.Fatalf("expected to detach bd-2, got %v", detach)
+	}
+	if len(attach) != 1 || *attach[0].BlockDeviceID != "bd-3" || *attach[0].Name != "logs" {
+		t.Fatalf("expected to attach bd-3 named logs, got %#v", attach)
+	}
+}
+
+func TestFlattenMachineDisks(t *testing.T) {
+	disks := flattenMachineDisks([]string{"bd-1", "bd-4"}, []interface{}{
+		map[string]interface{}{"block_device_id": "bd-1", "name": "data", "description": "database"},
+		map[string]interface{}{"block_device_id": "bd-2", "name": "logs", "description": ""},
+	})
+
+	// bd-2 was detached outside Terraform and bd-4 attached by another resource
+	expected := []map[string]interface{}{
+		{"block_device_id": "bd-1", "name": "data", "description": "database"},
+	}
+	if !reflect.DeepEqual(disks, expected) {
+		t.Fatalf("expected %v, got %v", expected, disks)
+	}
+}
+
+func TestResourceMachineDisksDiff(t *testing.T) {
+	diskSchema := resourceMachine().Schema["disks"]
+	hash := strconv.Itoa(schema.HashResource(diskSchema.Elem.(*schema.Resource))(map[string]interface{}{
+		"block_device_id": "bd-1", "name": "data", "description": "",
+	}))
+	state := &terraform.InstanceState{
+		ID: "m-1",
+		Attributes: map[string]string{
+			"id":                                 "m-1",
+			"name":                               "web",
+			"project_id":                         "p-1",
+			"image":                              "ubuntu",
+			"flavor":                             "small",
+			"nics.#":                             "0",
+			"disks.#":                            "1",
+			"disks." + hash + ".block_device_id": "bd-1",
+			"disks." + hash + ".name":            "data",
+			"disks." + hash + ".description":     "",
+		},
+	}
+
+	raw, err := config.NewRawConfig(map[string]interface{}{
+		"name":       "web",
+		"project_id": "p-1",
+		"image":      "ubuntu",
+		"flavor":     "small",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	diff, err := resourceMachine().Diff(state, terraform.NewResourceConfig(raw), nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if diff == nil || diff.Attributes["disks.#"] == nil || diff.Attributes["disks.#"].New != "0" {
+		t.Fatalf("expected removing the last disk to detach it, got %#v", diff)
+	}
+	if diff.RequiresNew() {
+		t.Fatalf("expected the disk to be detached in place, got %#v", diff)
+	}
+}
+
+func TestSubmitDiskAttachment(t *testing.T) {
+	var body map[string]interface{}
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost || r.URL.Path != "/iaas/api/machines/m-1/disks" {
+			t.Errorf("unexpected request %s %s", r.Method, r.URL)
+		}
+		json.NewDecoder(r.Body).Decode(&body)
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusAccepted)
+		w.Write([]byte(`{"id":"r-1","status":"INPROGRESS"}`))
+	}))
+	defer server.Close()
+
+	err := submitDiskAttachment(testServerAPIClient(server), "m-1", diskAttachmentSpecification{
+		BlockDeviceID: "bd-1",
+		Name:          "data",
+		UnitNumber:    "2",
+	})
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+
+	expected := map[string]interface{}{"blockDeviceId": "bd-1", "name": "data", "unitNumber": "2"}
+	if !reflect.DeepEqual(body, expected) {
+		t.Fatalf("expected %v to be sent, got %v", expected, body)
+	}
+}
@@ -0,0 +1,101 @@
+package vra
+
+import (
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/vmware/vra-sdk-go/pkg/client"
+	"github.com/vmware/vra-sdk-go/pkg/client/compute"
+	"github.com/vmware/vra-sdk-go/pkg/models"
+)
+
+// Day-2 operations of machines, named after their /operations/ API paths
+const (
+	machineOperationPowerOn  = "power-on"
+	machineOperationPowerOff = "power-off"
+	machineOperationSuspend  = "suspend"
+	machineOperationReboot   = "reboot"
+	machineOperationReset    = "reset"
+	machineOperationRestart  = "restart"
+	machineOperationShutdown = "shutdown"
+)
+
+// runMachineOperation runs a day-2 operation on a machine and waits for it on the request tracker
+func runMachineOperation(apiClient *client.MulticloudIaaS, id, operation string, timeout time.Duration) ([]trackedResource, error) {
+	var tracker *models.RequestTracker
+	switch operation {
+	case machineOperationPowerOn:
+		ret, err := apiClient.Compute.PowerOnMachine(compute.NewPowerOnMachineParams().WithID(id))
+		if err != nil {
+			return nil, err
+		}
+		tracker = ret.Payload
+	case machineOperationPowerOff:
+		ret, err := apiClient.Compute.PowerOffMachine(compute.NewPowerOffMachineParams().WithID(id))
+		if err != nil {
+			return nil, err
+		}
+		tracker = ret.Payload
+	case machineOperationSuspend:
+		ret, err := apiClient.Compute.SuspendMachine(compute.NewSuspendMachineParams().WithID(id))
+		if err != nil {
+			return nil, err
+		}
+		tracker = ret.Payload
+	case machineOperationReboot:
+		ret, err := apiClient.Compute.RebootMachine(compute.NewRebootMachineParams().WithID(id))
+		if err != nil {
+			return nil, err
+		}
+		tracker = ret.Payload
+	case machineOperationReset:
+		ret, err := apiClient.Compute.ResetMachine(compute.NewResetMachineParams().WithID(id))
+		if err != nil {
+			return nil, err
+		}
+		tracker = ret.Payload
+	case machineOperationRestart:
+		ret, err := apiClient.Compute.RestartMachine(compute.NewRestartMachineParams().WithID(id))
+		if err != nil {
+			return nil, err
+		}
+		tracker = ret.Payload
+	case machineOperationShutdown:
+		ret, err := apiClient.Compute.ShutdownMachine(compute.NewShutdownMachineParams().WithID(id))
+		if err != nil {
+			return nil, err
+		}
+		tracker = ret.Payload
+	default:
+		return nil, fmt.Errorf("unknown machine operation %s", operation)
+	}
+
+	resources, err := waitForRequestTracker(apiClient, *tracker.ID, timeout)
+	if err != nil {
+		return nil, fmt.Errorf("error running %s on machine %s: %v", operation, id, err)
+	}
+	return resources, nil
+}
+
+// resizeMachine resizes a machine to the given flavor, with optional CPU and memory
+// overrides left out when zero, and waits for it on the request tracker
+func resizeMachine(apiClient *client.MulticloudIaaS, id, flavor string, cpuCount, memoryInMB int, timeout time.Duration) error {
+	params := compute.NewResizeMachineParams().WithID(id).WithName(withString(flavor))
+	if cpuCount > 0 {
+		params = params.WithCPUCount(withString(strconv.Itoa(cpuCount)))
+	}
+	if memoryInMB > 0 {
+		params = params.WithMemoryInMB(withString(strconv.Itoa(memoryInMB)))
+	}
+
+	ret, err := apiClient.Compute.ResizeMachine(params)
+	if err != nil {
+		return fmt.Errorf("error resizing machine %s: %v", id, err)
+	}
+
+	if _, err := waitForRequestTracker(apiClient, *ret.Payload.ID, timeout); err != nil {
+		return fmt.Errorf("error resizing machine %s: %v", id, err)
+	}
+	return nil
+}
@@ -0,0 +1,75 @@
+package vra
+
+import (
+	"log"
+	"time"
+
+	"github.com/vmware/vra-sdk-go/pkg/client"
+	"github.com/vmware/vra-sdk-go/pkg/client/compute"
+	"github.com/vmware/vra-sdk-go/pkg/models"
+)
+
+// Power states accepted by the power_state argument of vra_machine
+const (
+	machinePowerStateOn        = "ON"
+	machinePowerStateOff       = "OFF"
+	machinePowerStateSuspended = "SUSPENDED"
+)
+
+// flattenMachinePowerState maps the power state reported by vRA to the power_state
+// values. A guest shut down counts as OFF, UNKNOWN is kept as is.
+func flattenMachinePowerState(powerState *string) string {
+	if powerState == nil {
+		return ""
+	}
+
+	switch *powerState {
+	case models.MachinePowerStateGUESTOFF:
+		return machinePowerStateOff
+	case models.MachinePowerStateSUSPEND:
+		return machinePowerStateSuspended
+	default:
+		return *powerState
+	}
+}
+
+// machinePowerOperations returns the day-2 operations moving a machine from the
+// current to the desired power state, in order
+func machinePowerOperations(current, desired string) []string {
+	if current == desired {
+		return nil
+	}
+
+	switch desired {
+	case machinePowerStateOn:
+		return []string{machineOperationPowerOn}
+	case machinePowerStateOff:
+		return []string{machineOperationPowerOff}
+	case machinePowerStateSuspended:
+		// Only a running machine can be suspended
+		if current != machinePowerStateOn {
+			return []string{machineOperationPowerOn, machineOperationSuspend}
+		}
+		return []string{machineOperationSuspend}
+	}
+	return nil
+}
+
+// setMachinePowerState runs the power operations needed to bring the machine to
+// the desired power state and waits for each of them on the request tracker
+func setMachinePowerState(apiClient *client.MulticloudIaaS, id, desired string, timeout time.Duration) error {
+	ret, err := apiClient.Compute.GetMachine(compute.NewGetMachineParams().WithID(id))
+	if err != nil {
+		return err
+	}
+
+	current := flattenMachinePowerState(ret.Payload.PowerState)
+	for _, operation := range machinePowerOperations(current, desired) {
+		log.Printf("[DEBUG] machine %s is %s, running %s to make it %s", id, current, operation, desired)
+
+		if _, err := runMachineOperation(apiClient, id, operation, timeout); err != nil {
+			return err
+		}
+	}
+	return nil
+}
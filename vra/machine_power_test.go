@@ -0,0 +1,40 @@
+package vra
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/vmware/vra-sdk-go/pkg/models"
+)
+
+func TestFlattenMachinePowerState(t *testing.T) {
+	cases := map[string]string{
+		models.MachinePowerStateON:       machinePowerStateOn,
+		models.MachinePowerStateGUESTOFF: machinePowerStateOff,
+		models.MachinePowerStateSUSPEND:  machinePowerStateSuspended,
+		models.MachinePowerStateUNKNOWN:  models.MachinePowerStateUNKNOWN,
+	}
+	for powerState, expected := range cases {
+		if s := flattenMachinePowerState(withString(powerState)); s != expected {
+			t.Fatalf("expected %s to be %s, got %s", powerState, expected, s)
+		}
+	}
+}
+
+func TestMachinePowerOperations(t *testing.T) {
+	cases := []struct {
+		current, desired string
+		operations       []string
+	}{
+		{machinePowerStateOn, machinePowerStateOn, nil},
+		{machinePowerStateOff, machinePowerStateOn, []string{machineOperationPowerOn}},
+		{machinePowerStateSuspended, machinePowerStateOff, []string{machineOperationPowerOff}},
+		{machinePowerStateOn, machinePowerStateSuspended, []string{machineOperationSuspend}},
+		{machinePowerStateOff, machinePowerStateSuspended, []string{machineOperationPowerOn, machineOperationSuspend}},
+	}
+	for _, c := range cases {
+		if operations := machinePowerOperations(c.current, c.desired); !reflect.DeepEqual(operations, c.operations) {
+			t.Fatalf("expected %v from %s to %s, got %v", c.operations, c.current, c.desired, operations)
+		}
+	}
+}
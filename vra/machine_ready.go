@@ -0,0 +1,124 @@
+package vra
+
+import (
+	"fmt"
+	"log"
+	"net"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/vmware/vra-sdk-go/pkg/client"
+	"github.com/vmware/vra-sdk-go/pkg/client/compute"
+	"github.com/vmware/vra-sdk-go/pkg/models"
+)
+
+const (
+	machineReady    = "ready"
+	machineNotReady = "waiting"
+)
+
+// machineReadiness is what a new machine waits for before its create completes
+type machineReadiness struct {
+	Address   bool
+	PoweredOn bool
+	// TCPPort is a port of the machine address to wait for, 0 to not wait
+	TCPPort int
+}
+
+func (r machineReadiness) empty() bool {
+	return !r.Address && !r.PoweredOn && r.TCPPort == 0
+}
+
+// remainingTimeout returns what is left of the timeout started at start, so that
+// the steps of a create share one budget, and an error when nothing is left for step
+func remainingTimeout(timeout time.Duration, start time.Time, step string) (time.Duration, error) {
+	remaining := timeout - time.Since(start)
+	if remaining <= 0 {
+		return 0, fmt.Errorf("timeout of %s reached before %s", timeout, step)
+	}
+	return remaining, nil
+}
+
+// customizeDiffMachineReadiness rejects at plan time a machine that waits to be
+// powered on while its desired power state keeps it off
+func customizeDiffMachineReadiness(d *schema.ResourceDiff, m interface{}) error {
+	if !d.Get("wait_for_power_on").(bool) || !d.NewValueKnown("power_state") {
+		return nil
+	}
+	if d.Id() != "" && !d.HasChange("power_state") && !d.HasChange("wait_for_power_on") {
+		return nil
+	}
+
+	if powerState := d.Get("power_state").(string); powerState != "" && powerState != machinePowerStateOn {
+		return fmt.Errorf("wait_for_power_on cannot be set when power_state is %s", powerState)
+	}
+	return nil
+}
+
+// machineNotReadyReasons returns why the machine does not meet the readiness conditions yet
+func machineNotReadyReasons(machine *models.Machine, readiness machineReadiness, dial func(address string) error) []string {
+	reasons := make([]string, 0)
+	if readiness.PoweredOn && flattenMachinePowerState(machine.PowerState) != machinePowerStateOn {
+		reasons = append(reasons, "not powered on")
+	}
+
+	if (readiness.Address || readiness.TCPPort > 0) && machine.Address == "" {
+		return append(reasons, "no address")
+	}
+
+	if readiness.TCPPort > 0 {
+		address := net.JoinHostPort(machine.Address, strconv.Itoa(readiness.TCPPort))
+		if err := dial(address); err != nil {
+			reasons = append(reasons, fmt.Sprintf("%s not reachable: %v", address, err))
+		}
+	}
+	return reasons
+}
+
+// dialTCP checks that a TCP connection to the address can be opened
+func dialTCP(address string) error {
+	conn, err := net.DialTimeout("tcp", address, 5*time.Second)
+	if err != nil {
+		return err
+	}
+	return conn.Close()
+}
+
+// waitForMachineReady polls the machine until it meets the readiness conditions
+func waitForMachineReady(apiClient *client.MulticloudIaaS, id string, readiness machineReadiness, timeout time.Duration) error {
+	if readiness.empty() {
+		return nil
+	}
+
+	var reasons []string
+	stateChangeFunc := resource.StateChangeConf{
+		Pending: []string{machineNotReady},
+		Refresh: func() (interface{}, string, error) {
+			ret, err := apiClient.Compute.GetMachine(compute.NewGetMachineParams().WithID(id))
+			if err != nil {
+				return nil, "", err
+			}
+
+			reasons = machineNotReadyReasons(ret.Payload, readiness, dialTCP)
+			if len(reasons) > 0 {
+				log.Printf("[DEBUG] machine %s is not ready: %s", id, strings.Join(reasons, ", "))
+				return ret.Payload, machineNotReady, nil
+			}
+			return ret.Payload, machineReady, nil
+		},
+		Target:     []string{machineReady},
+		Timeout:    timeout,
+		MinTimeout: 5 * time.Second,
+	}
+
+	if _, err := stateChangeFunc.WaitForState(); err != nil {
+		if len(reasons) > 0 {
+			return fmt.Errorf("machine %s is not ready: %s: %v", id, strings.Join(reasons, ", "), err)
+		}
+		return fmt.Errorf("error waiting for machine %s to be ready: %v", id, err)
+	}
+	return nil
+}
@@ -0,0 +1,110 @@
+package vra
+
+import (
+	"errors"
+	"net"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform/config"
+	"github.com/hashicorp/terraform/terraform"
+	"github.com/vmware/vra-sdk-go/pkg/models"
+)
+
+func TestMachineNotReadyReasons(t *testing.T) {
+	reachable := func(address string) error { return nil }
+	unreachable := func(address string) error { return errors.New("connection refused") }
+
+	cases := []struct {
+		name      string
+		machine   *models.Machine
+		readiness machineReadiness
+		dial      func(string) error
+		reasons   int
+	}{
+		{"nothing to wait for", &models.Machine{}, machineReadiness{}, unreachable, 0},
+		{"address", &models.Machine{Address: "10.0.0.1"}, machineReadiness{Address: true}, unreachable, 0},
+		{"no address", &models.Machine{}, machineReadiness{Address: true}, reachable, 1},
+		{"powered on", &models.Machine{PowerState: withString(models.MachinePowerStateON)}, machineReadiness{PoweredOn: true}, unreachable, 0},
+		{"powered off", &models.Machine{PowerState: withString(models.MachinePowerStateOFF)}, machineReadiness{PoweredOn: true}, reachable, 1},
+		{"port without address", &models.Machine{}, machineReadiness{TCPPort: 22}, reachable, 1},
+		{"port reachable", &models.Machine{Address: "10.0.0.1"}, machineReadiness{TCPPort: 22}, reachable, 0},
+		{"port unreachable", &models.Machine{Address: "10.0.0.1"}, machineReadiness{TCPPort: 22}, unreachable, 1},
+		{"off and unreachable", &models.Machine{Address: "10.0.0.1", PowerState: withString(models.MachinePowerStateOFF)}, machineReadiness{PoweredOn: true, TCPPort: 22}, unreachable, 2},
+	}
+	for _, c := range cases {
+		reasons := machineNotReadyReasons(c.machine, c.readiness, c.dial)
+		if len(reasons) != c.reasons {
+			t.Errorf("%s: expected %d reasons, got %v", c.name, c.reasons, reasons)
+		}
+	}
+}
+
+func TestDialTCP(t *testing.T) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	address := listener.Addr().String()
+
+	if err := dialTCP(address); err != nil {
+		t.Fatalf("expected %s to be reachable, got %v", address, err)
+	}
+
+	listener.Close()
+	if err := dialTCP(address); err == nil || !strings.Contains(err.Error(), "refused") {
+		t.Fatalf("expected %s to be refused once closed, got %v", address, err)
+	}
+}
+
+func TestRemainingTimeout(t *testing.T) {
+	remaining, err := remainingTimeout(10*time.Minute, time.Now().Add(-4*time.Minute), "resizing the machine")
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+	if remaining > 6*time.Minute || remaining < 5*time.Minute {
+		t.Fatalf("expected about 6 minutes left, got %s", remaining)
+	}
+
+	if _, err := remainingTimeout(10*time.Minute, time.Now().Add(-11*time.Minute), "resizing the machine"); err == nil {
+		t.Fatal("expected an error once the timeout is used up")
+	}
+}
+
+func TestCustomizeDiffMachineReadiness(t *testing.T) {
+	cases := []struct {
+		name   string
+		config map[string]interface{}
+		err    bool
+	}{
+		{"power on", map[string]interface{}{"wait_for_power_on": true, "power_state": "ON"}, false},
+		{"default power state", map[string]interface{}{"wait_for_power_on": true}, false},
+		{"power off", map[string]interface{}{"wait_for_power_on": true, "power_state": "OFF"}, true},
+		{"suspended", map[string]interface{}{"wait_for_power_on": true, "power_state": "SUSPENDED"}, true},
+		{"no wait", map[string]interface{}{"power_state": "OFF"}, false},
+	}
+	for _, c := range cases {
+		configMap := map[string]interface{}{
+			"name":       "machine",
+			"project_id": "p-1",
+			"image":      "ubuntu",
+			"flavor":     "small",
+		}
+		for k, v := range c.config {
+			configMap[k] = v
+		}
+		raw, err := config.NewRawConfig(configMap)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		_, err = resourceMachine().Diff(nil, terraform.NewResourceConfig(raw), nil)
+		if c.err && err == nil {
+			t.Errorf("%s: expected an error", c.name)
+		}
+		if !c.err && err != nil {
+			t.Errorf("%s: unexpected error %v", c.name, err)
+		}
+	}
+}
@@ -71,8 +71,8 @@ func expandNics(configNics []interface{}) []*models.NetworkInterfaceSpecificatio
 			nic.Description = v
 		}
 
-		if v, ok := nicMap["device_index"].(int32); ok && v != 0 {
-			nic.DeviceIndex = v
+		if v, ok := nicMap["device_index"].(int); ok && v != 0 {
+			nic.DeviceIndex = int32(v)
 		}
 
 		if v, ok := nicMap["addresses"].([]interface{}); ok && len(v) != 0 {
@@ -102,3 +102,46 @@ func expandNics(configNics []interface{}) []*models.NetworkInterfaceSpecificatio
 
 	return nics
 }
+
+// flattenNics flattens the network interfaces of a machine. Name, description,
+// security groups and custom properties are only used on creation and are kept
+// from the configuration of the interface on the same network, as are the
+// addresses and device index when vRA assigned them.
+func flattenNics(nics []*models.NetworkInterface, configNics []interface{}) []map[string]interface{} {
+	configured := make(map[string]map[string]interface{})
+	for _, configNic := range configNics {
+		nicMap := configNic.(map[string]interface{})
+		configured[nicMap["network_id"].(string)] = nicMap
+	}
+
+	result := make([]map[string]interface{}, 0, len(nics))
+	for _, nic := range nics {
+		networkID := linkID(nic.Links, "network")
+		helper := map[string]interface{}{
+			"network_id":         networkID,
+			"device_index":       int(nic.DeviceIndex),
+			"addresses":          nic.Addresses,
+			"security_group_ids": linkIDs(nic.Links, "security-groups"),
+		}
+
+		if nicMap, ok := configured[networkID]; ok {
+			helper["name"] = nicMap["name"]
+			helper["description"] = nicMap["description"]
+			helper["custom_properties"] = nicMap["custom_properties"]
+			helper["security_group_ids"] = nicMap["security_group_ids"]
+			if len(nicMap["addresses"].([]interface{})) == 0 {
+				helper["addresses"] = []string{}
+			}
+			if nicMap["device_index"].(int) == 0 {
+				helper["device_index"] = 0
+			}
+		} else {
+			helper["name"] = nic.Name
+			helper["description"] = nic.Description
+		}
+
+		result = append(result, helper)
+	}
+
+	return result
+}
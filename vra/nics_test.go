@@ -0,0 +1,58 @@
+package vra
+
+import (
+	"testing"
+
+	"github.com/vmware/vra-sdk-go/pkg/models"
+)
+
+func TestFlattenNics(t *testing.T) {
+	nics := []*models.NetworkInterface{
+		{
+			Name:        "my-machine-nic-0",
+			DeviceIndex: 0,
+			Addresses:   []string{"10.0.0.12"},
+			Links: map[string]models.Href{
+				"network":         {Href: "/iaas/api/networks/net-1"},
+				"security-groups": {Hrefs: []string{"/iaas/api/security-groups/sg-1"}},
+			},
+		},
+		{
+			Name:        "my-machine-nic-1",
+			DeviceIndex: 1,
+			Addresses:   []string{"10.1.0.7"},
+			Links: map[string]models.Href{
+				"network": {Href: "/iaas/api/networks/net-2"},
+			},
+		},
+	}
+	configNics := []interface{}{
+		map[string]interface{}{
+			"name":               "frontend",
+			"description":        "",
+			"device_index":       0,
+			"network_id":         "net-1",
+			"addresses":          []interface{}{},
+			"security_group_ids": []interface{}{"sg-2"},
+			"custom_properties":  map[string]interface{}{},
+		},
+	}
+
+	result := flattenNics(nics, configNics)
+	if len(result) != 2 {
+		t.Fatalf("expected 2 nics, got %v", result)
+	}
+
+	configured := result[0]
+	if configured["name"] != "frontend" || len(configured["addresses"].([]string)) != 0 {
+		t.Fatalf("expected the configured name and no assigned address, got %v", configured)
+	}
+	if ids := configured["security_group_ids"].([]interface{}); len(ids) != 1 || ids[0] != "sg-2" {
+		t.Fatalf("expected the configured security group sg-2, got %v", ids)
+	}
+
+	drifted := result[1]
+	if drifted["network_id"] != "net-2" || drifted["name"] != "my-machine-nic-1" || drifted["device_index"] != 1 {
+		t.Fatalf("expected the nic added outside Terraform to be read, got %v", drifted)
+	}
+}
@@ -0,0 +1,24 @@
+package vra
+
+import (
+	"log"
+	"net/http"
+
+	"github.com/go-openapi/runtime"
+	"github.com/hashicorp/terraform/helper/schema"
+)
+
+// isNotFound reports whether the error is an API error with a 404 status, for the
+// operations whose SDK reader has no NotFound response
+func isNotFound(err error) bool {
+	apiErr, ok := err.(*runtime.APIError)
+	return ok && apiErr.Code == http.StatusNotFound
+}
+
+// removeDeletedResource removes a resource deleted outside Terraform from the state
+// so that the plan creates it again instead of failing
+func removeDeletedResource(d *schema.ResourceData, resourceType string) error {
+	log.Printf("[WARN] %s %s no longer exists, removing it from state", resourceType, d.Id())
+	d.SetId("")
+	return nil
+}
@@ -0,0 +1,59 @@
+package vra
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/hashicorp/terraform/helper/schema"
+)
+
+// TestResourceReadNotFound checks that the Read of every resource removes an object
+// deleted outside Terraform from the state instead of failing the plan
+func TestResourceReadNotFound(t *testing.T) {
+	var requests []string
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests = append(requests, r.Method+" "+r.URL.Path)
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusNotFound)
+		w.Write([]byte(`{"message":"not found"}`))
+	}))
+	defer server.Close()
+
+	meta := &Client{apiClient: testServerAPIClient(server)}
+
+	// The resources identified by a parent object read it from their arguments
+	raw := map[string]interface{}{
+		"machine_id":      "m-1",
+		"block_device_id": "bd-1",
+	}
+
+	for name, r := range Provider().ResourcesMap {
+		r := r
+		t.Run(name, func(t *testing.T) {
+			requests = nil
+			config := make(map[string]interface{})
+			for k, v := range raw {
+				if _, ok := r.Schema[k]; ok {
+					config[k] = v
+				}
+			}
+
+			d := schema.TestResourceDataRaw(t, r.Schema, config)
+			d.SetId("missing")
+			if _, ok := r.Schema["machine_ids"]; ok {
+				d.Set("machine_ids", []string{"m-1"})
+			}
+
+			if err := r.Read(d, meta); err != nil {
+				t.Fatalf("expected a missing object to be removed from state, got %v", err)
+			}
+			if d.Id() != "" {
+				t.Fatalf("expected the ID to be cleared, got %q", d.Id())
+			}
+			if len(requests) == 0 {
+				t.Fatal("expected Read to query vRA")
+			}
+		})
+	}
+}
@@ -2,8 +2,11 @@ package vra
 
 import (
 	"errors"
+	"math"
+	"time"
 
 	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/hashicorp/terraform/helper/validation"
 )
 
 // Provider represents the VRA provider
@@ -19,23 +22,104 @@ func Provider() *schema.Provider {
 			"refresh_token": {
 				Type:          schema.TypeString,
 				Optional:      true,
-				ConflictsWith: []string{"access_token"},
+				ConflictsWith: []string{"access_token", "username"},
 				DefaultFunc:   schema.EnvDefaultFunc("VRA_REFRESH_TOKEN", nil),
 				Description:   "The refresh token for API operations.",
 			},
 			"access_token": {
 				Type:          schema.TypeString,
 				Optional:      true,
-				ConflictsWith: []string{"refresh_token"},
+				ConflictsWith: []string{"refresh_token", "username"},
 				DefaultFunc:   schema.EnvDefaultFunc("VRA_ACCESS_TOKEN", nil),
 				Description:   "The access token for API operations.",
 			},
+			"username": {
+				Type:          schema.TypeString,
+				Optional:      true,
+				ConflictsWith: []string{"refresh_token", "access_token"},
+				DefaultFunc:   schema.EnvDefaultFunc("VRA_USERNAME", nil),
+				Description:   "The user name to log in to an on-prem vRA 8 appliance.",
+			},
+			"password": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Sensitive:   true,
+				DefaultFunc: schema.EnvDefaultFunc("VRA_PASSWORD", nil),
+				Description: "The password of the user to log in to an on-prem vRA 8 appliance.",
+			},
+			"domain": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				DefaultFunc: schema.EnvDefaultFunc("VRA_DOMAIN", nil),
+				Description: "The identity domain of the user to log in to an on-prem vRA 8 appliance.",
+			},
 			"insecure": {
 				Type:        schema.TypeBool,
-				DefaultFunc: schema.EnvDefaultFunc("VRA7_INSECURE", nil),
+				DefaultFunc: schema.MultiEnvDefaultFunc([]string{"VRA_INSECURE", "VRA7_INSECURE"}, nil),
 				Optional:    true,
 				Description: "Specify whether to validate TLS certificates.",
 			},
+			"ca_file": {
+				Type:          schema.TypeString,
+				Optional:      true,
+				ConflictsWith: []string{"ca_pem"},
+				DefaultFunc:   schema.EnvDefaultFunc("VRA_CA_FILE", nil),
+				Description:   "The path to a PEM encoded CA bundle used to verify the TLS certificate of the endpoint.",
+			},
+			"ca_pem": {
+				Type:          schema.TypeString,
+				Optional:      true,
+				ConflictsWith: []string{"ca_file"},
+				DefaultFunc:   schema.EnvDefaultFunc("VRA_CA_PEM", nil),
+				Description:   "A PEM encoded CA bundle used to verify the TLS certificate of the endpoint.",
+			},
+			"client_cert_file": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				DefaultFunc: schema.EnvDefaultFunc("VRA_CLIENT_CERT_FILE", nil),
+				Description: "The path to a PEM encoded client certificate for TLS authentication.",
+			},
+			"client_key_file": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				DefaultFunc: schema.EnvDefaultFunc("VRA_CLIENT_KEY_FILE", nil),
+				Description: "The path to the PEM encoded private key of the client certificate.",
+			},
+			"max_retries": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				DefaultFunc:  schema.EnvDefaultFunc("VRA_MAX_RETRIES", 3),
+				ValidateFunc: validation.IntAtLeast(0),
+				Description:  "The maximum number of times a throttled or failed API request is retried.",
+			},
+			"retry_wait_min": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				DefaultFunc:  schema.EnvDefaultFunc("VRA_RETRY_WAIT_MIN", 1),
+				ValidateFunc: validation.IntAtLeast(1),
+				Description:  "The minimum time in seconds to wait before retrying an API request.",
+			},
+			"retry_wait_max": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				DefaultFunc:  schema.EnvDefaultFunc("VRA_RETRY_WAIT_MAX", 30),
+				ValidateFunc: validation.IntAtLeast(1),
+				Description:  "The maximum time in seconds to wait before retrying an API request.",
+			},
+			"max_concurrent_requests": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				DefaultFunc:  schema.EnvDefaultFunc("VRA_MAX_CONCURRENT_REQUESTS", 0),
+				ValidateFunc: validation.IntAtLeast(0),
+				Description:  "The maximum number of API requests in flight at the same time, 0 for no limit.",
+			},
+			"requests_per_second": {
+				Type:         schema.TypeFloat,
+				Optional:     true,
+				DefaultFunc:  schema.EnvDefaultFunc("VRA_REQUESTS_PER_SECOND", 0),
+				ValidateFunc: validation.FloatBetween(0, math.MaxFloat64),
+				Description:  "The maximum number of API requests sent per second, 0 for no limit.",
+			},
 		},
 
 		DataSourcesMap: map[string]*schema.Resource{
@@ -58,25 +142,30 @@ func Provider() *schema.Provider {
 		},
 
 		ResourcesMap: map[string]*schema.Resource{
-			"vra_block_device":          resourceBlockDevice(),
-			"vra_cloud_account_aws":     resourceCloudAccountAWS(),
-			"vra_cloud_account_azure":   resourceCloudAccountAzure(),
-			"vra_cloud_account_gcp":     resourceCloudAccountGCP(),
-			"vra_cloud_account_nsxt":    resourceCloudAccountNSXT(),
-			"vra_cloud_account_nsxv":    resourceCloudAccountNSXV(),
-			"vra_cloud_account_vmc":     resourceCloudAccountVMC(),
-			"vra_cloud_account_vsphere": resourceCloudAccountVsphere(),
-			"vra_flavor_profile":        resourceFlavorProfile(),
-			"vra_image_profile":         resourceImageProfile(),
-			"vra_load_balancer":         resourceLoadBalancer(),
-			"vra_machine":               resourceMachine(),
-			"vra_network":               resourceNetwork(),
-			"vra_network_profile":       resourceNetworkProfile(),
-			"vra_project":               resourceProject(),
-			"vra_storage_profile":       resourceStorageProfile(),
-			"vra_storage_profile_aws":   resourceStorageProfileAws(),
-			"vra_storage_profile_azure": resourceStorageProfileAzure(),
-			"vra_zone":                  resourceZone(),
+			"vra_block_device":            resourceBlockDevice(),
+			"vra_block_device_attachment": resourceBlockDeviceAttachment(),
+			"vra_block_device_snapshot":   resourceBlockDeviceSnapshot(),
+			"vra_cloud_account_aws":       resourceCloudAccountAWS(),
+			"vra_cloud_account_azure":     resourceCloudAccountAzure(),
+			"vra_cloud_account_gcp":       resourceCloudAccountGCP(),
+			"vra_cloud_account_nsxt":      resourceCloudAccountNSXT(),
+			"vra_cloud_account_nsxv":      resourceCloudAccountNSXV(),
+			"vra_cloud_account_vmc":       resourceCloudAccountVMC(),
+			"vra_cloud_account_vsphere":   resourceCloudAccountVsphere(),
+			"vra_flavor_profile":          resourceFlavorProfile(),
+			"vra_image_profile":           resourceImageProfile(),
+			"vra_load_balancer":           resourceLoadBalancer(),
+			"vra_machine":                 resourceMachine(),
+			"vra_machine_action":          resourceMachineAction(),
+			"vra_machine_group":           resourceMachineGroup(),
+			"vra_machine_snapshot":        resourceMachineSnapshot(),
+			"vra_network":                 resourceNetwork(),
+			"vra_network_profile":         resourceNetworkProfile(),
+			"vra_project":                 resourceProject(),
+			"vra_storage_profile":         resourceStorageProfile(),
+			"vra_storage_profile_aws":     resourceStorageProfileAws(),
+			"vra_storage_profile_azure":   resourceStorageProfileAzure(),
+			"vra_zone":                    resourceZone(),
 		},
 
 		ConfigureFunc: configureProvider,
@@ -87,6 +176,8 @@ func configureProvider(d *schema.ResourceData) (interface{}, error) {
 	url := d.Get("url").(string)
 	refreshToken := ""
 	accessToken := ""
+	username := ""
+	password := ""
 
 	if v, ok := d.GetOk("refresh_token"); ok {
 		refreshToken = v.(string)
@@ -96,15 +187,55 @@ func configureProvider(d *schema.ResourceData) (interface{}, error) {
 		accessToken = v.(string)
 	}
 
-	insecure := d.Get("insecure").(bool)
+	if v, ok := d.GetOk("username"); ok {
+		username = v.(string)
+	}
+
+	if v, ok := d.GetOk("password"); ok {
+		password = v.(string)
+	}
+
+	tlsOptions := TLSOptions{
+		Insecure:       d.Get("insecure").(bool),
+		CAFile:         d.Get("ca_file").(string),
+		CAPEM:          d.Get("ca_pem").(string),
+		ClientCertFile: d.Get("client_cert_file").(string),
+		ClientKeyFile:  d.Get("client_key_file").(string),
+	}
+
+	if (tlsOptions.ClientCertFile == "") != (tlsOptions.ClientKeyFile == "") {
+		return nil, errors.New("client_cert_file and client_key_file must be set together")
+	}
+
+	retryOptions := RetryOptions{
+		MaxRetries: d.Get("max_retries").(int),
+		WaitMin:    time.Duration(d.Get("retry_wait_min").(int)) * time.Second,
+		WaitMax:    time.Duration(d.Get("retry_wait_max").(int)) * time.Second,
+	}
+
+	if retryOptions.WaitMin > retryOptions.WaitMax {
+		return nil, errors.New("retry_wait_min must not be greater than retry_wait_max")
+	}
+
+	limitOptions := LimitOptions{
+		MaxConcurrentRequests: d.Get("max_concurrent_requests").(int),
+		RequestsPerSecond:     d.Get("requests_per_second").(float64),
+	}
+
+	if username != "" {
+		if password == "" {
+			return nil, errors.New("password required with username")
+		}
+		return NewClientFromPassword(url, username, password, d.Get("domain").(string), tlsOptions, retryOptions, limitOptions)
+	}
 
 	if accessToken == "" && refreshToken == "" {
-		return nil, errors.New("refresh_token or access_token required")
+		return nil, errors.New("refresh_token, access_token or username and password required")
 	}
 
 	if accessToken != "" {
-		return NewClientFromAccessToken(url, accessToken, insecure)
+		return NewClientFromAccessToken(url, accessToken, tlsOptions, retryOptions, limitOptions)
 	}
 
-	return NewClientFromRefreshToken(url, refreshToken, insecure)
+	return NewClientFromRefreshToken(url, refreshToken, tlsOptions, retryOptions, limitOptions)
 }
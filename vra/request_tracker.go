@@ -0,0 +1,188 @@
+package vra
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/vmware/vra-sdk-go/pkg/client"
+	"github.com/vmware/vra-sdk-go/pkg/client/request"
+	"github.com/vmware/vra-sdk-go/pkg/models"
+)
+
+// Resource types of the links reported by the request tracker
+const (
+	trackedMachines      = "machines"
+	trackedNetworks      = "networks"
+	trackedBlockDevices  = "block-devices"
+	trackedLoadBalancers = "load-balancers"
+	trackedSnapshots     = "snapshots"
+)
+
+// trackedResource is a resource created or changed by a request tracked by vRA
+type trackedResource struct {
+	// Type is the API collection of the resource, e.g. "machines"
+	Type string
+	ID   string
+}
+
+// parseTrackedResources parses resource links such as "/iaas/api/machines/<id>"
+func parseTrackedResources(links []string) []trackedResource {
+	resources := make([]trackedResource, 0, len(links))
+	for _, link := range links {
+		segments := strings.Split(strings.Trim(link, "/"), "/")
+		r := trackedResource{ID: segments[len(segments)-1]}
+		if len(segments) > 1 {
+			r.Type = segments[len(segments)-2]
+		}
+		resources = append(resources, r)
+	}
+	return resources
+}
+
+// trackedResourceIDs returns the IDs of the tracked resources of the given type
+func trackedResourceIDs(resources []trackedResource, resourceType string) []string {
+	ids := make([]string, 0, len(resources))
+	for _, r := range resources {
+		// The API is not consistent on plural collection names in links
+		if strings.TrimSuffix(r.Type, "s") == strings.TrimSuffix(resourceType, "s") {
+			ids = append(ids, r.ID)
+		}
+	}
+	return ids
+}
+
+// requestTrackerError is returned when a tracked request fails or does not finish in time
+type requestTrackerError struct {
+	RequestID string
+	Status    string
+	Message   string
+	Resources []trackedResource
+	// Err is the reason the request could not be waited on, nil when vRA reported a failure
+	Err error
+}
+
+func newRequestTrackerError(requestID string, tracker *models.RequestTracker, err error) *requestTrackerError {
+	e := &requestTrackerError{
+		RequestID: requestID,
+		Message:   tracker.Message,
+		Resources: parseTrackedResources(tracker.Resources),
+		Err:       err,
+	}
+	if tracker.ID != nil {
+		e.RequestID = *tracker.ID
+	}
+	if tracker.Status != nil {
+		e.Status = *tracker.Status
+	}
+	return e
+}
+
+// Error implements error
+func (e *requestTrackerError) Error() string {
+	if e.Err == nil {
+		return fmt.Sprintf("request %s failed: %s", e.RequestID, e.Message)
+	}
+
+	msg := fmt.Sprintf("request %s did not finish: %v", e.RequestID, e.Err)
+	if e.Status != "" {
+		msg = fmt.Sprintf("%s, last status %s", msg, e.Status)
+	}
+	if e.Message != "" {
+		msg = fmt.Sprintf("%s: %s", msg, e.Message)
+	}
+	return msg
+}
+
+// requestTrackerRefreshFunc polls the tracker of the given request
+func requestTrackerRefreshFunc(apiClient *client.MulticloudIaaS, requestID string) resource.StateRefreshFunc {
+	return func() (interface{}, string, error) {
+		ret, err := apiClient.Request.GetRequestTracker(request.NewGetRequestTrackerParams().WithID(requestID))
+		if err != nil {
+			return nil, models.RequestTrackerStatusFAILED, err
+		}
+
+		tracker := ret.Payload
+		if tracker.Status == nil {
+			return tracker, "", newRequestTrackerError(requestID, tracker, errors.New("request tracker returned no status"))
+		}
+
+		status := *tracker.Status
+		switch status {
+		case models.RequestTrackerStatusFAILED:
+			return tracker, status, newRequestTrackerError(requestID, tracker, nil)
+		case models.RequestTrackerStatusINPROGRESS, models.RequestTrackerStatusFINISHED:
+			return tracker, status, nil
+		default:
+			return tracker, status, newRequestTrackerError(requestID, tracker, fmt.Errorf("unknown status %v", status))
+		}
+	}
+}
+
+// waitForRequestTracker waits until the given request finishes and returns the
+// resources it created or changed. On failure the error is a *requestTrackerError
+// carrying the tracker message and the resources vRA reported so far.
+func waitForRequestTracker(apiClient *client.MulticloudIaaS, requestID string, timeout time.Duration) ([]trackedResource, error) {
+	var last *models.RequestTracker
+	refresh := requestTrackerRefreshFunc(apiClient, requestID)
+
+	stateChangeFunc := resource.StateChangeConf{
+		Delay:   5 * time.Second,
+		Pending: []string{models.RequestTrackerStatusINPROGRESS},
+		Refresh: func() (interface{}, string, error) {
+			tracker, status, err := refresh()
+			if tracker != nil {
+				last = tracker.(*models.RequestTracker)
+			}
+			return tracker, status, err
+		},
+		Target:     []string{models.RequestTrackerStatusFINISHED},
+		Timeout:    timeout,
+		MinTimeout: 5 * time.Second,
+	}
+
+	result, err := stateChangeFunc.WaitForState()
+	if err != nil {
+		if trackerErr, ok := err.(*requestTrackerError); ok {
+			return nil, trackerErr
+		}
+		if last == nil {
+			return nil, &requestTrackerError{RequestID: requestID, Err: err}
+		}
+		return nil, newRequestTrackerError(requestID, last, err)
+	}
+
+	return parseTrackedResources(result.(*models.RequestTracker).Resources), nil
+}
+
+// setPartialResourceID records in state the resource a failed create request
+// left behind, so the next apply destroys it as a tainted resource instead of
+// leaking it. It returns the error to report for the failed create.
+func setPartialResourceID(d *schema.ResourceData, err error, resourceType string) error {
+	trackerErr, ok := err.(*requestTrackerError)
+	if !ok {
+		return err
+	}
+
+	ids := trackedResourceIDs(trackerErr.Resources, resourceType)
+	if len(ids) == 0 {
+		return err
+	}
+
+	log.Printf("[WARN] request %s left %s %v behind, recording %s in state", trackerErr.RequestID, resourceType, ids, ids[0])
+	d.SetId(ids[0])
+	return fmt.Errorf("%v, the partially created resource %s was saved in state and will be replaced on the next apply", err, ids[0])
+}
+
+// createdResourceID returns the ID of the resource of the given type created by a finished request
+func createdResourceID(resources []trackedResource, resourceType, requestID string) (string, error) {
+	ids := trackedResourceIDs(resources, resourceType)
+	if len(ids) == 0 {
+		return "", fmt.Errorf("request %s finished without creating any %s", requestID, resourceType)
+	}
+	return ids[0], nil
+}
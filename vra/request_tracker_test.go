@@ -0,0 +1,84 @@
+package vra
+
+import (
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/vmware/vra-sdk-go/pkg/models"
+)
+
+func TestParseTrackedResources(t *testing.T) {
+	resources := parseTrackedResources([]string{
+		"/iaas/api/machines/m-1",
+		"/iaas/api/block-device/bd-1",
+		"/iaas/api/load-balancers/lb-1",
+	})
+
+	expected := []trackedResource{
+		{Type: "machines", ID: "m-1"},
+		{Type: "block-device", ID: "bd-1"},
+		{Type: "load-balancers", ID: "lb-1"},
+	}
+	if !reflect.DeepEqual(resources, expected) {
+		t.Fatalf("expected %v, got %v", expected, resources)
+	}
+
+	if ids := trackedResourceIDs(resources, trackedBlockDevices); !reflect.DeepEqual(ids, []string{"bd-1"}) {
+		t.Fatalf("expected block device bd-1, got %v", ids)
+	}
+
+	if _, err := createdResourceID(resources, trackedNetworks, "r-1"); err == nil {
+		t.Fatal("expected an error when the request created no network")
+	}
+}
+
+func TestRequestTrackerError(t *testing.T) {
+	tracker := &models.RequestTracker{
+		ID:      withString("r-1"),
+		Status:  withString(models.RequestTrackerStatusINPROGRESS),
+		Message: "Cloning template",
+	}
+
+	err := newRequestTrackerError("r-1", tracker, errors.New("timeout while waiting for state to become 'FINISHED'"))
+	for _, s := range []string{"r-1", "INPROGRESS", "Cloning template"} {
+		if !strings.Contains(err.Error(), s) {
+			t.Fatalf("expected %q in the error, got %q", s, err.Error())
+		}
+	}
+
+	tracker.Status = withString(models.RequestTrackerStatusFAILED)
+	tracker.Message = "No placement exists"
+	if err := newRequestTrackerError("r-1", tracker, nil); err.Error() != "request r-1 failed: No placement exists" {
+		t.Fatalf("unexpected error %q", err.Error())
+	}
+
+	tracker.ID = nil
+	if err := newRequestTrackerError("r-2", tracker, nil); err.RequestID != "r-2" {
+		t.Fatalf("expected the request ID to fall back to r-2, got %q", err.RequestID)
+	}
+}
+
+func TestSetPartialResourceID(t *testing.T) {
+	d := schema.TestResourceDataRaw(t, resourceMachine().Schema, map[string]interface{}{})
+
+	err := setPartialResourceID(d, &requestTrackerError{
+		RequestID: "r-1",
+		Resources: parseTrackedResources([]string{"/iaas/api/machines/m-1"}),
+		Err:       errors.New("timeout"),
+	}, trackedMachines)
+
+	if err == nil {
+		t.Fatal("expected the create to fail")
+	}
+	if d.Id() != "m-1" {
+		t.Fatalf("expected the partially created machine m-1 in state, got %q", d.Id())
+	}
+
+	d = schema.TestResourceDataRaw(t, resourceMachine().Schema, map[string]interface{}{})
+	if err := setPartialResourceID(d, errors.New("unauthorized"), trackedMachines); err == nil || d.Id() != "" {
+		t.Fatalf("expected no ID in state, got %q", d.Id())
+	}
+}
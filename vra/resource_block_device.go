@@ -3,24 +3,30 @@ package vra
 import (
 	"fmt"
 	"log"
-	"strings"
 	"time"
 
 	"github.com/vmware/vra-sdk-go/pkg/client"
 	"github.com/vmware/vra-sdk-go/pkg/client/disk"
-	"github.com/vmware/vra-sdk-go/pkg/client/request"
 	"github.com/vmware/vra-sdk-go/pkg/models"
 
-	"github.com/hashicorp/terraform/helper/resource"
 	"github.com/hashicorp/terraform/helper/schema"
 )
 
 func resourceBlockDevice() *schema.Resource {
 	return &schema.Resource{
-		Create: resourceBlockDeviceCreate,
-		Read:   resourceBlockDeviceRead,
-		Update: resourceBlockDeviceUpdate,
-		Delete: resourceBlockDeviceDelete,
+		Create:   resourceBlockDeviceCreate,
+		Read:     resourceBlockDeviceRead,
+		Update:   resourceBlockDeviceUpdate,
+		Delete:   resourceBlockDeviceDelete,
+		Importer: importByIDOrName(resourceBlockDeviceImportList),
+
+		CustomizeDiff: customizeDiffBlockDevice,
+
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(5 * time.Minute),
+			Update: schema.DefaultTimeout(5 * time.Minute),
+			Delete: schema.DefaultTimeout(5 * time.Minute),
+		},
 
 		Schema: map[string]*schema.Schema{
 			"capacity_in_gb": &schema.Schema{
@@ -28,21 +34,21 @@ func resourceBlockDevice() *schema.Resource {
 				Required: true,
 			},
 			"name": &schema.Schema{
-				Type:     schema.TypeString,
-				Required: true,
-				DiffSuppressFunc: func(k, old, new string, d *schema.ResourceData) bool {
-					return !strings.HasPrefix(new, old)
-				},
+				Type:             schema.TypeString,
+				Required:         true,
+				DiffSuppressFunc: suppressNameSuffix,
 			},
 			"project_id": &schema.Schema{
 				Type:     schema.TypeString,
 				Required: true,
+				ForceNew: true,
 			},
-			"constraints": constraintsSchema(),
+			"constraints": forceNew(constraintsSchema()),
 			"custom_properties": &schema.Schema{
-				Type:     schema.TypeMap,
-				Computed: true,
-				Optional: true,
+				Type:             schema.TypeMap,
+				Computed:         true,
+				Optional:         true,
+				DiffSuppressFunc: suppressServerCustomProperties,
 			},
 			"description": &schema.Schema{
 				Type:     schema.TypeString,
@@ -51,16 +57,29 @@ func resourceBlockDevice() *schema.Resource {
 			"disk_content_base_64": &schema.Schema{
 				Type:     schema.TypeString,
 				Optional: true,
+				ForceNew: true,
 			},
 			"encrypted": &schema.Schema{
 				Type:     schema.TypeBool,
 				Optional: true,
+				ForceNew: true,
 			},
 			"source_reference": &schema.Schema{
 				Type:     schema.TypeString,
 				Optional: true,
+				ForceNew: true,
 			},
 			"tags": tagsSchema(),
+			"deletion_protection": &schema.Schema{
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  false,
+			},
+			"force_delete": &schema.Schema{
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  false,
+			},
 			"created_at": &schema.Schema{
 				Type:     schema.TypeString,
 				Computed: true,
@@ -144,54 +163,22 @@ func resourceBlockDeviceCreate(d *schema.ResourceData, m interface{}) error {
 		return err
 	}
 
-	stateChangeFunc := resource.StateChangeConf{
-		Delay:      5 * time.Second,
-		Pending:    []string{models.RequestTrackerStatusINPROGRESS},
-		Refresh:    blockDeviceStateRefreshFunc(*apiClient, *createBlockDeviceCreated.Payload.ID),
-		Target:     []string{models.RequestTrackerStatusFINISHED},
-		Timeout:    5 * time.Minute,
-		MinTimeout: 5 * time.Second,
+	resources, err := waitForRequestTracker(apiClient, *createBlockDeviceCreated.Payload.ID, d.Timeout(schema.TimeoutCreate))
+	if err != nil {
+		return setPartialResourceID(d, err, trackedBlockDevices)
 	}
 
-	resourceIDs, err := stateChangeFunc.WaitForState()
+	id, err := createdResourceID(resources, trackedBlockDevices, *createBlockDeviceCreated.Payload.ID)
 	if err != nil {
 		return err
 	}
 
-	blockDeviceIDs := resourceIDs.([]string)
-	i := strings.LastIndex(blockDeviceIDs[0], "/")
-	blockDeviceID := blockDeviceIDs[0][i+1 : len(blockDeviceIDs[0])]
-	d.SetId(blockDeviceID)
+	d.SetId(id)
 	log.Printf("Finished to create vra_block_device resource with name %s", d.Get("name"))
 
 	return resourceBlockDeviceRead(d, m)
 }
 
-func blockDeviceStateRefreshFunc(apiClient client.MulticloudIaaS, id string) resource.StateRefreshFunc {
-	return func() (interface{}, string, error) {
-		ret, err := apiClient.Request.GetRequestTracker(request.NewGetRequestTrackerParams().WithID(id))
-		if err != nil {
-			return "", models.RequestTrackerStatusFAILED, err
-		}
-
-		status := ret.Payload.Status
-		switch *status {
-		case models.RequestTrackerStatusFAILED:
-			return []string{""}, *status, fmt.Errorf(ret.Payload.Message)
-		case models.RequestTrackerStatusINPROGRESS:
-			return [...]string{id}, *status, nil
-		case models.RequestTrackerStatusFINISHED:
-			loadBalancerIDs := make([]string, len(ret.Payload.Resources))
-			for i, r := range ret.Payload.Resources {
-				loadBalancerIDs[i] = strings.TrimPrefix(r, "/iaas/api/block-device/")
-			}
-			return loadBalancerIDs, *status, nil
-		default:
-			return [...]string{id}, ret.Payload.Message, fmt.Errorf("blockDeviceStateRefreshFunc: unknown status %v", *status)
-		}
-	}
-}
-
 func resourceBlockDeviceRead(d *schema.ResourceData, m interface{}) error {
 	log.Printf("Reading the vra_block_device resource with name %s", d.Get("name"))
 	apiClient := m.(*Client).apiClient
@@ -199,6 +186,10 @@ func resourceBlockDeviceRead(d *schema.ResourceData, m interface{}) error {
 	id := d.Id()
 	resp, err := apiClient.Disk.GetBlockDevice(disk.NewGetBlockDeviceParams().WithID(id))
 	if err != nil {
+		// The SDK has no NotFound response for block devices
+		if isNotFound(err) {
+			return removeDeletedResource(d, "vra_block_device")
+		}
 		return err
 	}
 
@@ -213,6 +204,7 @@ func resourceBlockDeviceRead(d *schema.ResourceData, m interface{}) error {
 	d.Set("name", blockDevice.Name)
 	d.Set("organization_id", blockDevice.OrganizationID)
 	d.Set("owner", blockDevice.Owner)
+	d.Set("project_id", blockDevice.ProjectID)
 	d.Set("status", blockDevice.Status)
 	d.Set("updated_at", blockDevice.UpdatedAt)
 
@@ -229,29 +221,48 @@ func resourceBlockDeviceRead(d *schema.ResourceData, m interface{}) error {
 }
 
 func resourceBlockDeviceUpdate(d *schema.ResourceData, m interface{}) error {
+	log.Printf("Starting to update the vra_block_device resource with name %s", d.Get("name"))
+	apiClient := m.(*Client).apiClient
+
+	// vRA resizes a block device and updates its other arguments in place, the plan
+	// rejects shrinking it
+	if d.HasChange("capacity_in_gb") {
+		if err := resizeBlockDevice(apiClient, d.Id(), d.Get("capacity_in_gb").(int), d.Timeout(schema.TimeoutUpdate)); err != nil {
+			return err
+		}
+	}
+
+	if d.HasChange("name") || d.HasChange("description") || d.HasChange("tags") || d.HasChange("custom_properties") {
+		blockDeviceUpdateSpecification := blockDeviceUpdateSpecification{
+			Name:             d.Get("name").(string),
+			Description:      d.Get("description").(string),
+			Tags:             expandTags(d.Get("tags").(*schema.Set).List()),
+			CustomProperties: expandCustomProperties(d.Get("custom_properties").(map[string]interface{})),
+		}
+
+		if err := updateBlockDevice(apiClient, d.Id(), blockDeviceUpdateSpecification); err != nil {
+			return fmt.Errorf("error updating block device %s: %v", d.Id(), err)
+		}
+	}
 
-	return fmt.Errorf("Updating a block device resource is not allowed")
+	log.Printf("Finished updating the vra_block_device resource with name %s", d.Get("name"))
+	return resourceBlockDeviceRead(d, m)
 }
 
 func resourceBlockDeviceDelete(d *schema.ResourceData, m interface{}) error {
 	log.Printf("Starting to delete the vra_block_device resource with name %s", d.Get("name"))
 	apiClient := m.(*Client).apiClient
 
+	if err := checkDeletionProtection(d, "vra_block_device"); err != nil {
+		return err
+	}
+
 	id := d.Id()
-	deleteBlockDevice, err := apiClient.Disk.DeleteBlockDevice(disk.NewDeleteBlockDeviceParams().WithID(id))
+	deleteBlockDevice, err := apiClient.Disk.DeleteBlockDevice(disk.NewDeleteBlockDeviceParams().WithID(id).WithContext(deleteContext(d)))
 	if err != nil {
 		return err
 	}
-	stateChangeFunc := resource.StateChangeConf{
-		Delay:      5 * time.Second,
-		Pending:    []string{models.RequestTrackerStatusINPROGRESS},
-		Refresh:    blockDeviceStateRefreshFunc(*apiClient, *deleteBlockDevice.Payload.ID),
-		Target:     []string{models.RequestTrackerStatusFINISHED},
-		Timeout:    5 * time.Minute,
-		MinTimeout: 5 * time.Second,
-	}
-
-	_, err = stateChangeFunc.WaitForState()
+	_, err = waitForRequestTracker(apiClient, *deleteBlockDevice.Payload.ID, d.Timeout(schema.TimeoutDelete))
 	if err != nil {
 		return err
 	}
@@ -260,3 +271,18 @@ func resourceBlockDeviceDelete(d *schema.ResourceData, m interface{}) error {
 	log.Printf("Finished deleting the vra_block_device resource with name %s", d.Get("name"))
 	return nil
 }
+
+func resourceBlockDeviceImportList(apiClient *client.MulticloudIaaS) ([]namedResource, error) {
+	resources := make([]namedResource, 0)
+	err := listPages(func(skip int) (int, int64, error) {
+		ret := new(models.BlockDeviceResult)
+		if err := listPage(apiClient, "getBlockDevices", "/iaas/api/block-devices", skip, ret); err != nil {
+			return 0, 0, err
+		}
+		for _, blockDevice := range ret.Content {
+			resources = append(resources, namedResource{ID: *blockDevice.ID, Name: blockDevice.Name})
+		}
+		return len(ret.Content), ret.TotalElements, nil
+	})
+	return resources, err
+}
@@ -0,0 +1,184 @@
+package vra
+
+import (
+	"fmt"
+	"log"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/vmware/vra-sdk-go/pkg/client/compute"
+	"github.com/vmware/vra-sdk-go/pkg/client/disk"
+	"github.com/vmware/vra-sdk-go/pkg/models"
+
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/hashicorp/terraform/helper/validation"
+)
+
+func resourceBlockDeviceAttachment() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceBlockDeviceAttachmentCreate,
+		Read:   resourceBlockDeviceAttachmentRead,
+		Delete: resourceBlockDeviceAttachmentDelete,
+		Importer: &schema.ResourceImporter{
+			State: resourceBlockDeviceAttachmentImport,
+		},
+
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(5 * time.Minute),
+			Delete: schema.DefaultTimeout(5 * time.Minute),
+		},
+
+		Schema: map[string]*schema.Schema{
+			"machine_id": &schema.Schema{
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+			"block_device_id": &schema.Schema{
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+			"name": &schema.Schema{
+				Type:     schema.TypeString,
+				Optional: true,
+				Computed: true,
+				ForceNew: true,
+			},
+			"device_index": &schema.Schema{
+				Type:         schema.TypeInt,
+				Optional:     true,
+				ForceNew:     true,
+				ValidateFunc: validation.IntAtLeast(1),
+				// Not every cloud reports the unit number of a disk, an attachment read
+				// without one shows no change for it
+				DiffSuppressFunc: func(k, old, new string, d *schema.ResourceData) bool {
+					return d.Id() != "" && old == "0"
+				},
+			},
+		},
+	}
+}
+
+func resourceBlockDeviceAttachmentCreate(d *schema.ResourceData, m interface{}) error {
+	apiClient := m.(*Client).apiClient
+
+	machineID := d.Get("machine_id").(string)
+	blockDeviceID := d.Get("block_device_id").(string)
+	log.Printf("Starting to attach block device %s to machine %s", blockDeviceID, machineID)
+
+	// vRA requires a name for the disk, the block device name is used when none is set
+	name := d.Get("name").(string)
+	if name == "" {
+		ret, err := apiClient.Disk.GetBlockDevice(disk.NewGetBlockDeviceParams().WithID(blockDeviceID))
+		if err != nil {
+			return fmt.Errorf("error reading block device %s: %v", blockDeviceID, err)
+		}
+		name = ret.Payload.Name
+	}
+
+	spec := diskAttachmentSpecification{
+		BlockDeviceID: blockDeviceID,
+		Name:          name,
+	}
+	if v, ok := d.GetOk("device_index"); ok {
+		spec.UnitNumber = strconv.Itoa(v.(int))
+	}
+
+	if err := attachBlockDevice(apiClient, machineID, spec, d.Timeout(schema.TimeoutCreate)); err != nil {
+		return err
+	}
+
+	d.Set("name", name)
+
+	d.SetId(blockDeviceAttachmentID(machineID, blockDeviceID))
+	log.Printf("Finished to attach block device %s to machine %s", blockDeviceID, machineID)
+
+	return resourceBlockDeviceAttachmentRead(d, m)
+}
+
+func resourceBlockDeviceAttachmentRead(d *schema.ResourceData, m interface{}) error {
+	apiClient := m.(*Client).apiClient
+
+	machineID := d.Get("machine_id").(string)
+	blockDeviceID := d.Get("block_device_id").(string)
+
+	blockDevices, err := getMachineDisks(apiClient, machineID)
+	if err != nil {
+		if _, err := apiClient.Compute.GetMachine(compute.NewGetMachineParams().WithID(machineID)); err != nil {
+			switch err.(type) {
+			case *compute.GetMachineNotFound:
+				return removeDeletedResource(d, "vra_block_device_attachment")
+			}
+		}
+		return fmt.Errorf("error reading the disks of machine %s: %v", machineID, err)
+	}
+
+	// The attachment is gone once the machine no longer lists the block device
+	for _, blockDevice := range blockDevices {
+		if *blockDevice.ID == blockDeviceID {
+			setBlockDeviceAttachment(d, blockDevice)
+			return nil
+		}
+	}
+
+	return removeDeletedResource(d, "vra_block_device_attachment")
+}
+
+// setBlockDeviceAttachment sets the name and device index of an attachment from the
+// disk of the machine
+func setBlockDeviceAttachment(d *schema.ResourceData, blockDevice *models.BlockDevice) {
+	d.Set("name", blockDevice.Name)
+	if unitNumber, err := strconv.Atoi(blockDevice.CustomProperties["unitNumber"]); err == nil {
+		d.Set("device_index", unitNumber)
+	}
+}
+
+func resourceBlockDeviceAttachmentDelete(d *schema.ResourceData, m interface{}) error {
+	apiClient := m.(*Client).apiClient
+
+	machineID := d.Get("machine_id").(string)
+	blockDeviceID := d.Get("block_device_id").(string)
+	log.Printf("Starting to detach block device %s from machine %s", blockDeviceID, machineID)
+
+	// Nothing to detach when the machine or the attachment is already gone
+	if err := resourceBlockDeviceAttachmentRead(d, m); err != nil {
+		return err
+	}
+	if d.Id() == "" {
+		return nil
+	}
+
+	if err := detachMachineDisk(apiClient, machineID, blockDeviceID, d.Timeout(schema.TimeoutDelete)); err != nil {
+		return err
+	}
+
+	d.SetId("")
+	log.Printf("Finished to detach block device %s from machine %s", blockDeviceID, machineID)
+	return nil
+}
+
+// resourceBlockDeviceAttachmentImport imports an attachment from a "<machine_id>/<block_device_id>" ID
+func resourceBlockDeviceAttachmentImport(d *schema.ResourceData, m interface{}) ([]*schema.ResourceData, error) {
+	machineID, blockDeviceID, err := parseBlockDeviceAttachmentID(d.Id())
+	if err != nil {
+		return nil, err
+	}
+
+	d.Set("machine_id", machineID)
+	d.Set("block_device_id", blockDeviceID)
+	return []*schema.ResourceData{d}, nil
+}
+
+func blockDeviceAttachmentID(machineID, blockDeviceID string) string {
+	return machineID + "/" + blockDeviceID
+}
+
+func parseBlockDeviceAttachmentID(id string) (string, string, error) {
+	parts := strings.Split(id, "/")
+	if len(parts) != 2 || parts[0] == "" || parts[1] == "" {
+		return "", "", fmt.Errorf("expected an ID of the form <machine_id>/<block_device_id>, got %q", id)
+	}
+	return parts[0], parts[1], nil
+}
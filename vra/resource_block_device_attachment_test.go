@@ -0,0 +1,106 @@
+package vra
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform/helper/acctest"
+	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/hashicorp/terraform/terraform"
+	"github.com/vmware/vra-sdk-go/pkg/models"
+)
+
+func TestAccVRABlockDeviceAttachment_Basic(t *testing.T) {
+	rInt := acctest.RandInt()
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheckMachine(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckVRABlockDeviceAttachmentDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccCheckVRABlockDeviceAttachmentConfig(rInt),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					testAccCheckVRABlockDeviceAttachmentExists("vra_block_device_attachment.data"),
+				),
+			},
+			{
+				ResourceName:      "vra_block_device_attachment.data",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func TestParseBlockDeviceAttachmentID(t *testing.T) {
+	machineID, blockDeviceID, err := parseBlockDeviceAttachmentID(blockDeviceAttachmentID("m-1", "bd-1"))
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+	if machineID != "m-1" || blockDeviceID != "bd-1" {
+		t.Fatalf("expected m-1 and bd-1, got %s and %s", machineID, blockDeviceID)
+	}
+
+	for _, id := range []string{"bd-1", "m-1/", "/bd-1", "m-1/bd-1/x"} {
+		if _, _, err := parseBlockDeviceAttachmentID(id); err == nil {
+			t.Errorf("expected %q to be rejected", id)
+		}
+	}
+}
+
+func TestSetBlockDeviceAttachment(t *testing.T) {
+	d := schema.TestResourceDataRaw(t, resourceBlockDeviceAttachment().Schema, map[string]interface{}{})
+	setBlockDeviceAttachment(d, &models.BlockDevice{
+		Name:             "data",
+		CustomProperties: map[string]string{"unitNumber": "2"},
+	})
+	if d.Get("name") != "data" || d.Get("device_index") != 2 {
+		t.Fatalf("expected name data at device index 2, got %v at %v", d.Get("name"), d.Get("device_index"))
+	}
+
+	d = schema.TestResourceDataRaw(t, resourceBlockDeviceAttachment().Schema, map[string]interface{}{})
+	setBlockDeviceAttachment(d, &models.BlockDevice{Name: "data"})
+	if d.Get("device_index") != 0 {
+		t.Fatalf("expected no device index without a unit number, got %v", d.Get("device_index"))
+	}
+}
+
+func testAccCheckVRABlockDeviceAttachmentExists(n string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[n]
+		if !ok {
+			return fmt.Errorf("not found: %s", n)
+		}
+
+		apiClient := testAccProviderVRA.Meta().(*Client).apiClient
+		blockDeviceIDs, err := getMachineDiskIDs(apiClient, rs.Primary.Attributes["machine_id"])
+		if err != nil {
+			return err
+		}
+		for _, id := range blockDeviceIDs {
+			if id == rs.Primary.Attributes["block_device_id"] {
+				return nil
+			}
+		}
+		return fmt.Errorf("block device %s is not attached to machine %s", rs.Primary.Attributes["block_device_id"], rs.Primary.Attributes["machine_id"])
+	}
+}
+
+func testAccCheckVRABlockDeviceAttachmentDestroy(s *terraform.State) error {
+	if err := testAccCheckVRAMachineDestroy(s); err != nil {
+		return err
+	}
+	return testAccCheckVRABlockDeviceDestroy(s)
+}
+
+func testAccCheckVRABlockDeviceAttachmentConfig(rInt int) string {
+	return testAccCheckVRAMachineConfig(rInt) + testAccCheckVRABlockDeviceConfig(rInt) + `
+
+resource "vra_block_device_attachment" "data" {
+	machine_id      = vra_machine.my_machine.id
+	block_device_id = vra_block_device.my_block_device.id
+	name            = "data"
+}`
+}
@@ -0,0 +1,187 @@
+package vra
+
+import (
+	"fmt"
+	"log"
+	"strings"
+	"time"
+
+	"github.com/vmware/vra-sdk-go/pkg/client"
+
+	"github.com/hashicorp/terraform/helper/schema"
+)
+
+func resourceBlockDeviceSnapshot() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceBlockDeviceSnapshotCreate,
+		Read:   resourceBlockDeviceSnapshotRead,
+		Delete: resourceBlockDeviceSnapshotDelete,
+		Importer: &schema.ResourceImporter{
+			State: resourceBlockDeviceSnapshotImport,
+		},
+
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(5 * time.Minute),
+			Delete: schema.DefaultTimeout(5 * time.Minute),
+		},
+
+		Schema: map[string]*schema.Schema{
+			"block_device_id": &schema.Schema{
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+			"name": &schema.Schema{
+				Type:     schema.TypeString,
+				Optional: true,
+				Computed: true,
+				ForceNew: true,
+			},
+			"description": &schema.Schema{
+				Type:     schema.TypeString,
+				Optional: true,
+				ForceNew: true,
+			},
+			"self_link": &schema.Schema{
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"created_at": &schema.Schema{
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"owner": &schema.Schema{
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"organization_id": &schema.Schema{
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+		},
+	}
+}
+
+func resourceBlockDeviceSnapshotCreate(d *schema.ResourceData, m interface{}) error {
+	log.Printf("Starting to create vra_block_device_snapshot resource")
+	apiClient := m.(*Client).apiClient
+
+	blockDeviceID := d.Get("block_device_id").(string)
+	existing, err := getBlockDeviceSnapshotIDs(apiClient, blockDeviceID)
+	if err != nil {
+		return err
+	}
+
+	requestID, err := createBlockDeviceSnapshot(apiClient, blockDeviceID, diskSnapshotSpecification{
+		Name:        d.Get("name").(string),
+		Description: d.Get("description").(string),
+	})
+	if err != nil {
+		return fmt.Errorf("error creating snapshot of block device %s: %v", blockDeviceID, err)
+	}
+
+	resources, err := waitForRequestTracker(apiClient, requestID, d.Timeout(schema.TimeoutCreate))
+	if err != nil {
+		return setPartialResourceID(d, err, trackedSnapshots)
+	}
+
+	// The request may only report the block device, the new snapshot is then the
+	// one the block device did not have before
+	id, err := createdResourceID(resources, trackedSnapshots, requestID)
+	if err != nil {
+		snapshotIDs, listErr := getBlockDeviceSnapshotIDs(apiClient, blockDeviceID)
+		if listErr != nil {
+			return listErr
+		}
+		id, err = newSnapshotID(existing, snapshotIDs)
+		if err != nil {
+			return fmt.Errorf("error finding the snapshot request %s created on block device %s: %v", requestID, blockDeviceID, err)
+		}
+	}
+
+	d.SetId(id)
+	log.Printf("Finished to create vra_block_device_snapshot resource with name %s", d.Get("name"))
+
+	return resourceBlockDeviceSnapshotRead(d, m)
+}
+
+func resourceBlockDeviceSnapshotRead(d *schema.ResourceData, m interface{}) error {
+	log.Printf("Reading the vra_block_device_snapshot resource with name %s", d.Get("name"))
+	apiClient := m.(*Client).apiClient
+
+	blockDeviceID := d.Get("block_device_id").(string)
+	snapshot, err := getBlockDeviceSnapshot(apiClient, blockDeviceID, d.Id())
+	if err != nil {
+		return err
+	}
+	if snapshot == nil {
+		return removeDeletedResource(d, "vra_block_device_snapshot")
+	}
+
+	d.Set("name", snapshot.Name)
+	d.Set("description", snapshot.Description)
+	d.Set("created_at", snapshot.CreatedAt)
+	d.Set("owner", snapshot.Owner)
+	d.Set("organization_id", snapshot.OrganizationID)
+
+	// The self link is what vra_block_device takes as source_reference to restore the snapshot
+	selfLink := fmt.Sprintf("/iaas/api/block-devices/%s/snapshots/%s", blockDeviceID, d.Id())
+	if self, ok := snapshot.Links["self"]; ok && self.Href != "" {
+		selfLink = self.Href
+	}
+	d.Set("self_link", selfLink)
+
+	log.Printf("Finished reading the vra_block_device_snapshot resource with name %s", d.Get("name"))
+	return nil
+}
+
+func resourceBlockDeviceSnapshotDelete(d *schema.ResourceData, m interface{}) error {
+	log.Printf("Starting to delete the vra_block_device_snapshot resource with name %s", d.Get("name"))
+	apiClient := m.(*Client).apiClient
+
+	requestID, err := deleteBlockDeviceSnapshot(apiClient, d.Get("block_device_id").(string), d.Id())
+	if err != nil {
+		if isNotFound(err) {
+			d.SetId("")
+			return nil
+		}
+		return fmt.Errorf("error deleting snapshot %s of block device %s: %v", d.Id(), d.Get("block_device_id"), err)
+	}
+
+	_, err = waitForRequestTracker(apiClient, requestID, d.Timeout(schema.TimeoutDelete))
+	if err != nil {
+		return err
+	}
+
+	d.SetId("")
+	log.Printf("Finished deleting the vra_block_device_snapshot resource with name %s", d.Get("name"))
+	return nil
+}
+
+// resourceBlockDeviceSnapshotImport imports a snapshot from a "<block_device_id>/<snapshot_id>" ID
+func resourceBlockDeviceSnapshotImport(d *schema.ResourceData, m interface{}) ([]*schema.ResourceData, error) {
+	parts := strings.Split(d.Id(), "/")
+	if len(parts) != 2 || parts[0] == "" || parts[1] == "" {
+		return nil, fmt.Errorf("expected an ID of the form <block_device_id>/<snapshot_id>, got %q", d.Id())
+	}
+
+	d.Set("block_device_id", parts[0])
+	d.SetId(parts[1])
+	return []*schema.ResourceData{d}, nil
+}
+
+// getBlockDeviceSnapshotIDs returns the IDs of the snapshots of a block device
+func getBlockDeviceSnapshotIDs(apiClient *client.MulticloudIaaS, blockDeviceID string) (map[string]bool, error) {
+	snapshots, err := getBlockDeviceSnapshots(apiClient, blockDeviceID)
+	if err != nil {
+		return nil, err
+	}
+
+	ids := make(map[string]bool)
+	for _, snapshot := range snapshots {
+		if snapshot.ID != nil {
+			ids[*snapshot.ID] = true
+		}
+	}
+	return ids, nil
+}
@@ -0,0 +1,82 @@
+package vra
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform/helper/acctest"
+	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/hashicorp/terraform/terraform"
+)
+
+func TestAccVRABlockDeviceSnapshot_Basic(t *testing.T) {
+	rInt := acctest.RandInt()
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckVRABlockDeviceSnapshotDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccCheckVRABlockDeviceSnapshotConfig(rInt),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					testAccCheckVRABlockDeviceSnapshotExists("vra_block_device_snapshot.before_upgrade"),
+					resource.TestCheckResourceAttr(
+						"vra_block_device_snapshot.before_upgrade", "description", "before upgrade"),
+					resource.TestCheckResourceAttrSet(
+						"vra_block_device_snapshot.before_upgrade", "self_link"),
+					testAccCheckVRABlockDeviceExists("vra_block_device.staging"),
+				),
+			},
+		},
+	})
+}
+
+func testAccCheckVRABlockDeviceSnapshotExists(n string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[n]
+		if !ok {
+			return fmt.Errorf("not found: %s", n)
+		}
+
+		if rs.Primary.ID == "" {
+			return fmt.Errorf("no snapshot ID is set")
+		}
+
+		return nil
+	}
+}
+
+func testAccCheckVRABlockDeviceSnapshotDestroy(s *terraform.State) error {
+	apiClient := testAccProviderVRA.Meta().(*Client).apiClient
+
+	for _, rs := range s.RootModule().Resources {
+		if rs.Type == "vra_block_device_snapshot" {
+			snapshot, err := getBlockDeviceSnapshot(apiClient, rs.Primary.Attributes["block_device_id"], rs.Primary.ID)
+			if err != nil && !isNotFound(err) {
+				return err
+			}
+			if snapshot != nil {
+				return fmt.Errorf("Resource 'vra_block_device_snapshot' still exists with id %s", rs.Primary.ID)
+			}
+		}
+	}
+
+	return testAccCheckVRABlockDeviceDestroy(s)
+}
+
+func testAccCheckVRABlockDeviceSnapshotConfig(rInt int) string {
+	return testAccCheckVRABlockDeviceConfig(rInt) + fmt.Sprintf(`
+
+resource "vra_block_device_snapshot" "before_upgrade" {
+	block_device_id = vra_block_device.my_block_device.id
+	description     = "before upgrade"
+}
+
+resource "vra_block_device" "staging" {
+	name             = "terraform_vra_block_device-staging-%d"
+	project_id       = vra_block_device.my_block_device.project_id
+	capacity_in_gb   = vra_block_device.my_block_device.capacity_in_gb
+	source_reference = vra_block_device_snapshot.before_upgrade.self_link
+}`, rInt)
+}
@@ -3,6 +3,7 @@ package vra
 import (
 	"fmt"
 
+	"github.com/vmware/vra-sdk-go/pkg/client"
 	"github.com/vmware/vra-sdk-go/pkg/client/cloud_account"
 	"github.com/vmware/vra-sdk-go/pkg/models"
 
@@ -11,10 +12,11 @@ import (
 
 func resourceCloudAccountAWS() *schema.Resource {
 	return &schema.Resource{
-		Create: resourceCloudAccountAWSCreate,
-		Read:   resourceCloudAccountAWSRead,
-		Update: resourceCloudAccountAWSUpdate,
-		Delete: resourceCloudAccountAWSDelete,
+		Create:   resourceCloudAccountAWSCreate,
+		Read:     resourceCloudAccountAWSRead,
+		Update:   resourceCloudAccountAWSUpdate,
+		Delete:   resourceCloudAccountAWSDelete,
+		Importer: importByIDOrName(resourceCloudAccountAWSImportList),
 
 		Schema: map[string]*schema.Schema{
 			"access_key": &schema.Schema{
@@ -109,8 +111,7 @@ func resourceCloudAccountAWSRead(d *schema.ResourceData, m interface{}) error {
 	if err != nil {
 		switch err.(type) {
 		case *cloud_account.GetAwsCloudAccountNotFound:
-			d.SetId("")
-			return nil
+			return removeDeletedResource(d, "vra_cloud_account_aws")
 		}
 		return err
 	}
@@ -178,3 +179,18 @@ func resourceCloudAccountAWSDelete(d *schema.ResourceData, m interface{}) error
 
 	return nil
 }
+
+func resourceCloudAccountAWSImportList(apiClient *client.MulticloudIaaS) ([]namedResource, error) {
+	resources := make([]namedResource, 0)
+	err := listPages(func(skip int) (int, int64, error) {
+		ret := new(models.CloudAccountAwsResult)
+		if err := listPage(apiClient, "getAwsCloudAccounts", "/iaas/api/cloud-accounts-aws", skip, ret); err != nil {
+			return 0, 0, err
+		}
+		for _, cloudAccount := range ret.Content {
+			resources = append(resources, namedResource{ID: *cloudAccount.ID, Name: cloudAccount.Name})
+		}
+		return len(ret.Content), ret.TotalElements, nil
+	})
+	return resources, err
+}
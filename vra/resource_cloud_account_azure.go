@@ -3,6 +3,7 @@ package vra
 import (
 	"fmt"
 
+	"github.com/vmware/vra-sdk-go/pkg/client"
 	"github.com/vmware/vra-sdk-go/pkg/client/cloud_account"
 	"github.com/vmware/vra-sdk-go/pkg/models"
 
@@ -11,10 +12,11 @@ import (
 
 func resourceCloudAccountAzure() *schema.Resource {
 	return &schema.Resource{
-		Create: resourceCloudAccountAzureCreate,
-		Read:   resourceCloudAccountAzureRead,
-		Update: resourceCloudAccountAzureUpdate,
-		Delete: resourceCloudAccountAzureDelete,
+		Create:   resourceCloudAccountAzureCreate,
+		Read:     resourceCloudAccountAzureRead,
+		Update:   resourceCloudAccountAzureUpdate,
+		Delete:   resourceCloudAccountAzureDelete,
+		Importer: importByIDOrName(resourceCloudAccountAzureImportList),
 
 		Schema: map[string]*schema.Schema{
 
@@ -105,8 +107,7 @@ func resourceCloudAccountAzureRead(d *schema.ResourceData, m interface{}) error
 	if err != nil {
 		switch err.(type) {
 		case *cloud_account.GetAzureCloudAccountNotFound:
-			d.SetId("")
-			return nil
+			return removeDeletedResource(d, "vra_cloud_account_azure")
 		}
 		return err
 	}
@@ -178,3 +179,18 @@ func resourceCloudAccountAzureDelete(d *schema.ResourceData, m interface{}) erro
 
 	return nil
 }
+
+func resourceCloudAccountAzureImportList(apiClient *client.MulticloudIaaS) ([]namedResource, error) {
+	resources := make([]namedResource, 0)
+	err := listPages(func(skip int) (int, int64, error) {
+		ret := new(models.CloudAccountAzureResult)
+		if err := listPage(apiClient, "getAzureCloudAccounts", "/iaas/api/cloud-accounts-azure", skip, ret); err != nil {
+			return 0, 0, err
+		}
+		for _, cloudAccount := range ret.Content {
+			resources = append(resources, namedResource{ID: *cloudAccount.ID, Name: cloudAccount.Name})
+		}
+		return len(ret.Content), ret.TotalElements, nil
+	})
+	return resources, err
+}
@@ -3,6 +3,7 @@ package vra
 import (
 	"fmt"
 
+	"github.com/vmware/vra-sdk-go/pkg/client"
 	"github.com/vmware/vra-sdk-go/pkg/client/cloud_account"
 	"github.com/vmware/vra-sdk-go/pkg/models"
 
@@ -11,10 +12,11 @@ import (
 
 func resourceCloudAccountGCP() *schema.Resource {
 	return &schema.Resource{
-		Create: resourceCloudAccountGCPCreate,
-		Read:   resourceCloudAccountGCPRead,
-		Update: resourceCloudAccountGCPUpdate,
-		Delete: resourceCloudAccountGCPDelete,
+		Create:   resourceCloudAccountGCPCreate,
+		Read:     resourceCloudAccountGCPRead,
+		Update:   resourceCloudAccountGCPUpdate,
+		Delete:   resourceCloudAccountGCPDelete,
+		Importer: importByIDOrName(resourceCloudAccountGCPImportList),
 
 		Schema: map[string]*schema.Schema{
 
@@ -103,8 +105,7 @@ func resourceCloudAccountGCPRead(d *schema.ResourceData, m interface{}) error {
 	if err != nil {
 		switch err.(type) {
 		case *cloud_account.GetGcpCloudAccountNotFound:
-			d.SetId("")
-			return nil
+			return removeDeletedResource(d, "vra_cloud_account_gcp")
 		}
 		return err
 	}
@@ -176,3 +177,18 @@ func resourceCloudAccountGCPDelete(d *schema.ResourceData, m interface{}) error
 
 	return nil
 }
+
+func resourceCloudAccountGCPImportList(apiClient *client.MulticloudIaaS) ([]namedResource, error) {
+	resources := make([]namedResource, 0)
+	err := listPages(func(skip int) (int, int64, error) {
+		ret := new(models.CloudAccountGcpResult)
+		if err := listPage(apiClient, "getGcpCloudAccounts", "/iaas/api/cloud-accounts-gcp", skip, ret); err != nil {
+			return 0, 0, err
+		}
+		for _, cloudAccount := range ret.Content {
+			resources = append(resources, namedResource{ID: *cloudAccount.ID, Name: cloudAccount.Name})
+		}
+		return len(ret.Content), ret.TotalElements, nil
+	})
+	return resources, err
+}
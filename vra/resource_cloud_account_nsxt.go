@@ -3,6 +3,7 @@ package vra
 import (
 	"fmt"
 
+	"github.com/vmware/vra-sdk-go/pkg/client"
 	"github.com/vmware/vra-sdk-go/pkg/client/cloud_account"
 	"github.com/vmware/vra-sdk-go/pkg/models"
 
@@ -11,10 +12,11 @@ import (
 
 func resourceCloudAccountNSXT() *schema.Resource {
 	return &schema.Resource{
-		Create: resourceCloudAccountNSXTCreate,
-		Read:   resourceCloudAccountNSXTRead,
-		Update: resourceCloudAccountNSXTUpdate,
-		Delete: resourceCloudAccountNSXTDelete,
+		Create:   resourceCloudAccountNSXTCreate,
+		Read:     resourceCloudAccountNSXTRead,
+		Update:   resourceCloudAccountNSXTUpdate,
+		Delete:   resourceCloudAccountNSXTDelete,
+		Importer: importByIDOrName(resourceCloudAccountNSXTImportList),
 
 		Schema: map[string]*schema.Schema{
 			"accept_self_signed_cert": &schema.Schema{
@@ -88,8 +90,7 @@ func resourceCloudAccountNSXTRead(d *schema.ResourceData, m interface{}) error {
 	if err != nil {
 		switch err.(type) {
 		case *cloud_account.GetNsxTCloudAccountNotFound:
-			d.SetId("")
-			return nil
+			return removeDeletedResource(d, "vra_cloud_account_nsxt")
 		}
 		return err
 	}
@@ -97,6 +98,7 @@ func resourceCloudAccountNSXTRead(d *schema.ResourceData, m interface{}) error {
 
 	d.Set("dc_id", nsxtAccount.Dcid)
 	d.Set("description", nsxtAccount.Description)
+	d.Set("hostname", nsxtAccount.HostName)
 	d.Set("name", nsxtAccount.Name)
 	d.Set("username", nsxtAccount.Username)
 
@@ -136,3 +138,18 @@ func resourceCloudAccountNSXTDelete(d *schema.ResourceData, m interface{}) error
 
 	return nil
 }
+
+func resourceCloudAccountNSXTImportList(apiClient *client.MulticloudIaaS) ([]namedResource, error) {
+	resources := make([]namedResource, 0)
+	err := listPages(func(skip int) (int, int64, error) {
+		ret := new(models.CloudAccountNsxTResult)
+		if err := listPage(apiClient, "getNsxTCloudAccounts", "/iaas/api/cloud-accounts-nsx-t", skip, ret); err != nil {
+			return 0, 0, err
+		}
+		for _, cloudAccount := range ret.Content {
+			resources = append(resources, namedResource{ID: *cloudAccount.ID, Name: cloudAccount.Name})
+		}
+		return len(ret.Content), ret.TotalElements, nil
+	})
+	return resources, err
+}
@@ -3,6 +3,7 @@ package vra
 import (
 	"fmt"
 
+	"github.com/vmware/vra-sdk-go/pkg/client"
 	"github.com/vmware/vra-sdk-go/pkg/client/cloud_account"
 	"github.com/vmware/vra-sdk-go/pkg/models"
 
@@ -11,10 +12,11 @@ import (
 
 func resourceCloudAccountNSXV() *schema.Resource {
 	return &schema.Resource{
-		Create: resourceCloudAccountNSXVCreate,
-		Read:   resourceCloudAccountNSXVRead,
-		Update: resourceCloudAccountNSXVUpdate,
-		Delete: resourceCloudAccountNSXVDelete,
+		Create:   resourceCloudAccountNSXVCreate,
+		Read:     resourceCloudAccountNSXVRead,
+		Update:   resourceCloudAccountNSXVUpdate,
+		Delete:   resourceCloudAccountNSXVDelete,
+		Importer: importByIDOrName(resourceCloudAccountNSXVImportList),
 
 		Schema: map[string]*schema.Schema{
 			"accept_self_signed_cert": &schema.Schema{
@@ -88,8 +90,7 @@ func resourceCloudAccountNSXVRead(d *schema.ResourceData, m interface{}) error {
 	if err != nil {
 		switch err.(type) {
 		case *cloud_account.GetNsxVCloudAccountNotFound:
-			d.SetId("")
-			return nil
+			return removeDeletedResource(d, "vra_cloud_account_nsxv")
 		}
 		return err
 	}
@@ -97,6 +98,7 @@ func resourceCloudAccountNSXVRead(d *schema.ResourceData, m interface{}) error {
 
 	d.Set("dc_id", nsxvAccount.Dcid)
 	d.Set("description", nsxvAccount.Description)
+	d.Set("hostname", nsxvAccount.HostName)
 	d.Set("name", nsxvAccount.Name)
 	d.Set("username", nsxvAccount.Username)
 
@@ -136,3 +138,18 @@ func resourceCloudAccountNSXVDelete(d *schema.ResourceData, m interface{}) error
 
 	return nil
 }
+
+func resourceCloudAccountNSXVImportList(apiClient *client.MulticloudIaaS) ([]namedResource, error) {
+	resources := make([]namedResource, 0)
+	err := listPages(func(skip int) (int, int64, error) {
+		ret := new(models.CloudAccountNsxVResult)
+		if err := listPage(apiClient, "getNsxVCloudAccounts", "/iaas/api/cloud-accounts-nsx-v", skip, ret); err != nil {
+			return 0, 0, err
+		}
+		for _, cloudAccount := range ret.Content {
+			resources = append(resources, namedResource{ID: *cloudAccount.ID, Name: cloudAccount.Name})
+		}
+		return len(ret.Content), ret.TotalElements, nil
+	})
+	return resources, err
+}
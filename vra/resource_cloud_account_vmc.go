@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"strconv"
 
+	"github.com/vmware/vra-sdk-go/pkg/client"
 	"github.com/vmware/vra-sdk-go/pkg/client/cloud_account"
 	"github.com/vmware/vra-sdk-go/pkg/models"
 
@@ -12,10 +13,11 @@ import (
 
 func resourceCloudAccountVMC() *schema.Resource {
 	return &schema.Resource{
-		Create: resourceCloudAccountVMCCreate,
-		Read:   resourceCloudAccountVMCRead,
-		Update: resourceCloudAccountVMCUpdate,
-		Delete: resourceCloudAccountVMCDelete,
+		Create:   resourceCloudAccountVMCCreate,
+		Read:     resourceCloudAccountVMCRead,
+		Update:   resourceCloudAccountVMCUpdate,
+		Delete:   resourceCloudAccountVMCDelete,
+		Importer: importByIDOrName(resourceCloudAccountVMCImportList),
 
 		Schema: map[string]*schema.Schema{
 			"accept_self_signed_cert": &schema.Schema{
@@ -141,13 +143,16 @@ func resourceCloudAccountVMCRead(d *schema.ResourceData, m interface{}) error {
 	if err != nil {
 		switch err.(type) {
 		case *cloud_account.GetCloudAccountNotFound:
-			d.SetId("")
-			return nil
+			return removeDeletedResource(d, "vra_cloud_account_vmc")
 		}
 		return err
 	}
 	vmcAccount := *ret.Payload
 
+	if v, ok := vmcAccount.CloudAccountProperties["acceptSelfSignedCertificate"]; ok {
+		acceptSelfSignedCert, _ := strconv.ParseBool(v)
+		d.Set("accept_self_signed_cert", acceptSelfSignedCert)
+	}
 	d.Set("dc_id", vmcAccount.CloudAccountProperties["dcId"])
 	d.Set("description", vmcAccount.Description)
 	d.Set("name", vmcAccount.Name)
@@ -214,3 +219,21 @@ func resourceCloudAccountVMCDelete(d *schema.ResourceData, m interface{}) error
 
 	return nil
 }
+
+func resourceCloudAccountVMCImportList(apiClient *client.MulticloudIaaS) ([]namedResource, error) {
+	resources := make([]namedResource, 0)
+	err := listPages(func(skip int) (int, int64, error) {
+		ret := new(models.CloudAccountResult)
+		if err := listPage(apiClient, "getCloudAccounts", "/iaas/api/cloud-accounts", skip, ret); err != nil {
+			return 0, 0, err
+		}
+		for _, cloudAccount := range ret.Content {
+			if cloudAccount.CloudAccountType == nil || *cloudAccount.CloudAccountType != "vmc" {
+				continue
+			}
+			resources = append(resources, namedResource{ID: *cloudAccount.ID, Name: cloudAccount.Name})
+		}
+		return len(ret.Content), ret.TotalElements, nil
+	})
+	return resources, err
+}
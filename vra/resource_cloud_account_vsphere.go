@@ -3,6 +3,7 @@ package vra
 import (
 	"fmt"
 
+	"github.com/vmware/vra-sdk-go/pkg/client"
 	"github.com/vmware/vra-sdk-go/pkg/client/cloud_account"
 	"github.com/vmware/vra-sdk-go/pkg/models"
 
@@ -11,10 +12,11 @@ import (
 
 func resourceCloudAccountVsphere() *schema.Resource {
 	return &schema.Resource{
-		Create: resourceCloudAccountVsphereCreate,
-		Read:   resourceCloudAccountVsphereRead,
-		Update: resourceCloudAccountVsphereUpdate,
-		Delete: resourceCloudAccountVsphereDelete,
+		Create:   resourceCloudAccountVsphereCreate,
+		Read:     resourceCloudAccountVsphereRead,
+		Update:   resourceCloudAccountVsphereUpdate,
+		Delete:   resourceCloudAccountVsphereDelete,
+		Importer: importByIDOrName(resourceCloudAccountVsphereImportList),
 
 		Schema: map[string]*schema.Schema{
 			"accept_self_signed_cert": &schema.Schema{
@@ -121,8 +123,7 @@ func resourceCloudAccountVsphereRead(d *schema.ResourceData, m interface{}) erro
 	if err != nil {
 		switch err.(type) {
 		case *cloud_account.GetVSphereCloudAccountNotFound:
-			d.SetId("")
-			return nil
+			return removeDeletedResource(d, "vra_cloud_account_vsphere")
 		}
 		return err
 	}
@@ -131,6 +132,7 @@ func resourceCloudAccountVsphereRead(d *schema.ResourceData, m interface{}) erro
 	// d.Set("accept_self_signed_cert", vsphereAccount.AcceptSelfSignedCertificate)
 	d.Set("dcid", vsphereAccount.Dcid)
 	d.Set("description", vsphereAccount.Description)
+	d.Set("hostname", vsphereAccount.HostName)
 	d.Set("name", vsphereAccount.Name)
 	d.Set("username", vsphereAccount.Username)
 
@@ -191,3 +193,18 @@ func resourceCloudAccountVsphereDelete(d *schema.ResourceData, m interface{}) er
 
 	return nil
 }
+
+func resourceCloudAccountVsphereImportList(apiClient *client.MulticloudIaaS) ([]namedResource, error) {
+	resources := make([]namedResource, 0)
+	err := listPages(func(skip int) (int, int64, error) {
+		ret := new(models.CloudAccountVsphereResult)
+		if err := listPage(apiClient, "getVSphereCloudAccounts", "/iaas/api/cloud-accounts-vsphere", skip, ret); err != nil {
+			return 0, 0, err
+		}
+		for _, cloudAccount := range ret.Content {
+			resources = append(resources, namedResource{ID: *cloudAccount.ID, Name: cloudAccount.Name})
+		}
+		return len(ret.Content), ret.TotalElements, nil
+	})
+	return resources, err
+}
@@ -1,17 +1,21 @@
 package vra
 
 import (
+	"fmt"
+
 	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/vmware/vra-sdk-go/pkg/client"
 	"github.com/vmware/vra-sdk-go/pkg/client/flavor_profile"
 	"github.com/vmware/vra-sdk-go/pkg/models"
 )
 
 func resourceFlavorProfile() *schema.Resource {
 	return &schema.Resource{
-		Create: resourceFlavorProfileCreate,
-		Read:   resourceFlavorProfileRead,
-		Update: resourceFlavorProfileUpdate,
-		Delete: resourceFlavorProfileDelete,
+		Create:   resourceFlavorProfileCreate,
+		Read:     resourceFlavorProfileRead,
+		Update:   resourceFlavorProfileUpdate,
+		Delete:   resourceFlavorProfileDelete,
+		Importer: importByIDOrName(resourceFlavorProfileImportList),
 
 		Schema: map[string]*schema.Schema{
 			"description": &schema.Schema{
@@ -85,15 +89,24 @@ func resourceFlavorProfileRead(d *schema.ResourceData, m interface{}) error {
 	if err != nil {
 		switch err.(type) {
 		case *flavor_profile.GetFlavorProfileNotFound:
-			d.SetId("")
-			return nil
+			return removeDeletedResource(d, "vra_flavor_profile")
 		}
 		return err
 	}
 	flavor := *ret.Payload
 	d.Set("description", flavor.Description)
 	d.Set("name", flavor.Name)
-	d.Set("flavor_mappings", flattenFlavors(flavor.FlavorMappings.Mapping))
+	if regionID := linkID(flavor.Links, "region"); regionID != "" {
+		d.Set("region_id", regionID)
+	}
+
+	var mappings map[string]models.FabricFlavor
+	if flavor.FlavorMappings != nil {
+		mappings = flavor.FlavorMappings.Mapping
+	}
+	if err := d.Set("flavor_mapping", flattenFlavors(mappings, d.Get("flavor_mapping").(*schema.Set).List())); err != nil {
+		return fmt.Errorf("error setting flavor profile mappings - error: %v", err)
+	}
 
 	return nil
 }
@@ -151,17 +164,55 @@ func expandFlavors(configFlavors []interface{}) map[string]models.FabricFlavorDe
 	return flavors
 }
 
-func flattenFlavors(list map[string]models.FabricFlavor) []map[string]interface{} {
+// flattenFlavors flattens the flavor mappings returned by vRA. The API returns both
+// the instance type and the sizing of every mapping, so only the fields used by
+// the current mapping of the same name are kept. Imported mappings fall back to
+// the instance type when there is one.
+func flattenFlavors(list map[string]models.FabricFlavor, configFlavors []interface{}) []map[string]interface{} {
+	bySize := make(map[string]bool)
+	for _, configFlavor := range configFlavors {
+		flavor := configFlavor.(map[string]interface{})
+		bySize[flavor["name"].(string)] = flavor["instance_type"].(string) == ""
+	}
+
 	result := make([]map[string]interface{}, 0, len(list))
-	for _, flavor := range list {
+	for name, flavor := range list {
 		l := map[string]interface{}{
-			"cpu_count":     flavor.CPUCount,
-			"instance_type": flavor.ID,
-			"memory":        flavor.MemoryInMB,
-			"name":          flavor.Name,
+			"name": name,
+		}
+
+		instanceType := ""
+		if flavor.Name != nil {
+			instanceType = *flavor.Name
+		}
+		sized, ok := bySize[name]
+		if !ok {
+			sized = instanceType == ""
+		}
+
+		if sized {
+			l["cpu_count"] = int(flavor.CPUCount)
+			l["memory"] = int(flavor.MemoryInMB)
+		} else {
+			l["instance_type"] = instanceType
 		}
 
 		result = append(result, l)
 	}
 	return result
 }
+
+func resourceFlavorProfileImportList(apiClient *client.MulticloudIaaS) ([]namedResource, error) {
+	resources := make([]namedResource, 0)
+	err := listPages(func(skip int) (int, int64, error) {
+		ret := new(models.FlavorProfileResult)
+		if err := listPage(apiClient, "getFlavorProfiles", "/iaas/api/flavor-profiles", skip, ret); err != nil {
+			return 0, 0, err
+		}
+		for _, flavorProfile := range ret.Content {
+			resources = append(resources, namedResource{ID: *flavorProfile.ID, Name: flavorProfile.Name})
+		}
+		return len(ret.Content), ret.TotalElements, nil
+	})
+	return resources, err
+}
@@ -37,6 +37,11 @@ func TestAccVRAFlavorProfileBasic(t *testing.T) {
 						"vra_flavor_profile.my-flavor-profile", "flavor_mapping.310071531.instance_type", "t2.medium"),
 				),
 			},
+			{
+				ResourceName:      "vra_flavor_profile.my-flavor-profile",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
 		},
 	})
 }
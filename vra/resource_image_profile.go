@@ -1,17 +1,22 @@
 package vra
 
 import (
+	"fmt"
+	"strconv"
+
 	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/vmware/vra-sdk-go/pkg/client"
 	"github.com/vmware/vra-sdk-go/pkg/client/image_profile"
 	"github.com/vmware/vra-sdk-go/pkg/models"
 )
 
 func resourceImageProfile() *schema.Resource {
 	return &schema.Resource{
-		Create: resourceImageProfileCreate,
-		Read:   resourceImageProfileRead,
-		Update: resourceImageProfileUpdate,
-		Delete: resourceImageProfileDelete,
+		Create:   resourceImageProfileCreate,
+		Read:     resourceImageProfileRead,
+		Update:   resourceImageProfileUpdate,
+		Delete:   resourceImageProfileDelete,
+		Importer: importByIDOrName(resourceImageProfileImportList),
 
 		Schema: map[string]*schema.Schema{
 			"description": &schema.Schema{
@@ -106,15 +111,24 @@ func resourceImageProfileRead(d *schema.ResourceData, m interface{}) error {
 	if err != nil {
 		switch err.(type) {
 		case *image_profile.GetImageProfileNotFound:
-			d.SetId("")
-			return nil
+			return removeDeletedResource(d, "vra_image_profile")
 		}
 		return err
 	}
 	image := *ret.Payload
 	d.Set("description", image.Description)
 	d.Set("name", image.Name)
-	d.Set("image_mapping", flattenImageMapping(image.ImageMappings.Mapping))
+	if regionID := linkID(image.Links, "region"); regionID != "" {
+		d.Set("region_id", regionID)
+	}
+
+	var mappings map[string]models.ImageMappingDescription
+	if image.ImageMappings != nil {
+		mappings = image.ImageMappings.Mapping
+	}
+	if err := d.Set("image_mapping", flattenImageMapping(mappings, d.Get("image_mapping").(*schema.Set).List())); err != nil {
+		return fmt.Errorf("error setting image profile mappings - error: %v", err)
+	}
 
 	return nil
 }
@@ -169,20 +183,51 @@ func expandImageMapping(configImageMappings []interface{}) map[string]models.Fab
 	return images
 }
 
-func flattenImageMapping(list map[string]models.ImageMappingDescription) []map[string]interface{} {
+// flattenImageMapping flattens the image mappings returned by vRA. An image is
+// referenced either by ID or by name, the one used by the current mapping of the
+// same name is kept. Imported mappings reference the image by ID.
+func flattenImageMapping(list map[string]models.ImageMappingDescription, configImageMappings []interface{}) []map[string]interface{} {
+	byName := make(map[string]bool)
+	for _, configImageMapping := range configImageMappings {
+		image := configImageMapping.(map[string]interface{})
+		byName[image["name"].(string)] = image["image_id"].(string) == "" && image["image_name"].(string) != ""
+	}
+
 	result := make([]map[string]interface{}, 0, len(list))
-	for _, image := range list {
+	for name, image := range list {
 		l := map[string]interface{}{
-			"description":        image.Description,
-			"id":                 image.ID,
+			"name":               name,
+			"cloud_config":       image.CloudConfig,
 			"external_id":        image.ExternalID,
 			"external_region_id": image.ExternalRegionID,
 			"organization":       image.OrganizationID,
 			"os_family":          image.OsFamily,
 			"owner":              image.Owner,
-			"private":            image.IsPrivate,
+			"private":            strconv.FormatBool(image.IsPrivate),
+		}
+
+		if byName[name] || image.ID == nil {
+			l["image_name"] = image.Name
+		} else {
+			l["image_id"] = *image.ID
 		}
+
 		result = append(result, l)
 	}
 	return result
 }
+
+func resourceImageProfileImportList(apiClient *client.MulticloudIaaS) ([]namedResource, error) {
+	resources := make([]namedResource, 0)
+	err := listPages(func(skip int) (int, int64, error) {
+		ret := new(models.ImageProfileResult)
+		if err := listPage(apiClient, "getImageProfiles", "/iaas/api/image-profiles", skip, ret); err != nil {
+			return 0, 0, err
+		}
+		for _, imageProfile := range ret.Content {
+			resources = append(resources, namedResource{ID: *imageProfile.ID, Name: imageProfile.Name})
+		}
+		return len(ret.Content), ret.TotalElements, nil
+	})
+	return resources, err
+}
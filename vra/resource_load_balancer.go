@@ -8,19 +8,24 @@ import (
 
 	"github.com/vmware/vra-sdk-go/pkg/client"
 	"github.com/vmware/vra-sdk-go/pkg/client/load_balancer"
-	"github.com/vmware/vra-sdk-go/pkg/client/request"
 	"github.com/vmware/vra-sdk-go/pkg/models"
 
-	"github.com/hashicorp/terraform/helper/resource"
 	"github.com/hashicorp/terraform/helper/schema"
 )
 
 func resourceLoadBalancer() *schema.Resource {
 	return &schema.Resource{
-		Create: resourceLoadBalancerCreate,
-		Read:   resourceLoadBalancerRead,
-		Update: resourceLoadBalancerUpdate,
-		Delete: resourceLoadBalancerDelete,
+		Create:   resourceLoadBalancerCreate,
+		Read:     resourceLoadBalancerRead,
+		Update:   resourceLoadBalancerUpdate,
+		Delete:   resourceLoadBalancerDelete,
+		Importer: importByIDOrName(resourceLoadBalancerImportList),
+
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(5 * time.Minute),
+			Update: schema.DefaultTimeout(5 * time.Minute),
+			Delete: schema.DefaultTimeout(5 * time.Minute),
+		},
 
 		Schema: map[string]*schema.Schema{
 			"name": &schema.Schema{
@@ -141,54 +146,22 @@ func resourceLoadBalancerCreate(d *schema.ResourceData, m interface{}) error {
 		return err
 	}
 
-	stateChangeFunc := resource.StateChangeConf{
-		Delay:      5 * time.Second,
-		Pending:    []string{models.RequestTrackerStatusINPROGRESS},
-		Refresh:    loadBalancerStateRefreshFunc(*apiClient, *createLoadBalancerCreated.Payload.ID),
-		Target:     []string{models.RequestTrackerStatusFINISHED},
-		Timeout:    5 * time.Minute,
-		MinTimeout: 5 * time.Second,
+	resources, err := waitForRequestTracker(apiClient, *createLoadBalancerCreated.Payload.ID, d.Timeout(schema.TimeoutCreate))
+	if err != nil {
+		return setPartialResourceID(d, err, trackedLoadBalancers)
 	}
 
-	resourceIDs, err := stateChangeFunc.WaitForState()
+	id, err := createdResourceID(resources, trackedLoadBalancers, *createLoadBalancerCreated.Payload.ID)
 	if err != nil {
 		return err
 	}
 
-	loadBalancerIDs := resourceIDs.([]string)
-	i := strings.LastIndex(loadBalancerIDs[0], "/")
-	loadBalancerID := loadBalancerIDs[0][i+1 : len(loadBalancerIDs[0])]
-	d.SetId(loadBalancerID)
+	d.SetId(id)
 	log.Printf("Finished to create vra_load_balancer resource with name %s", d.Get("name"))
 
 	return resourceLoadBalancerRead(d, m)
 }
 
-func loadBalancerStateRefreshFunc(apiClient client.MulticloudIaaS, id string) resource.StateRefreshFunc {
-	return func() (interface{}, string, error) {
-		ret, err := apiClient.Request.GetRequestTracker(request.NewGetRequestTrackerParams().WithID(id))
-		if err != nil {
-			return "", models.RequestTrackerStatusFAILED, err
-		}
-
-		status := ret.Payload.Status
-		switch *status {
-		case models.RequestTrackerStatusFAILED:
-			return []string{""}, *status, fmt.Errorf(ret.Payload.Message)
-		case models.RequestTrackerStatusINPROGRESS:
-			return [...]string{id}, *status, nil
-		case models.RequestTrackerStatusFINISHED:
-			loadBalancerIDs := make([]string, len(ret.Payload.Resources))
-			for i, r := range ret.Payload.Resources {
-				loadBalancerIDs[i] = strings.TrimPrefix(r, "/iaas/api/load-balancer/")
-			}
-			return loadBalancerIDs, *status, nil
-		default:
-			return [...]string{id}, ret.Payload.Message, fmt.Errorf("loadBalancerStateRefreshFunc: unknown status %v", *status)
-		}
-	}
-}
-
 func resourceLoadBalancerRead(d *schema.ResourceData, m interface{}) error {
 	log.Printf("Reading the vra_load_balancer resource with name %s", d.Get("name"))
 	apiClient := m.(*Client).apiClient
@@ -198,8 +171,7 @@ func resourceLoadBalancerRead(d *schema.ResourceData, m interface{}) error {
 	if err != nil {
 		switch err.(type) {
 		case *load_balancer.GetLoadBalancerNotFound:
-			d.SetId("")
-			return nil
+			return removeDeletedResource(d, "vra_load_balancer")
 		}
 		return err
 	}
@@ -247,16 +219,7 @@ func resourceLoadBalancerDelete(d *schema.ResourceData, m interface{}) error {
 	if err != nil {
 		return err
 	}
-	stateChangeFunc := resource.StateChangeConf{
-		Delay:      5 * time.Second,
-		Pending:    []string{models.RequestTrackerStatusINPROGRESS},
-		Refresh:    loadBalancerStateRefreshFunc(*apiClient, *deleteLoadBalancer.Payload.ID),
-		Target:     []string{models.RequestTrackerStatusFINISHED},
-		Timeout:    5 * time.Minute,
-		MinTimeout: 5 * time.Second,
-	}
-
-	_, err = stateChangeFunc.WaitForState()
+	_, err = waitForRequestTracker(apiClient, *deleteLoadBalancer.Payload.ID, d.Timeout(schema.TimeoutDelete))
 	if err != nil {
 		return err
 	}
@@ -265,3 +228,18 @@ func resourceLoadBalancerDelete(d *schema.ResourceData, m interface{}) error {
 	log.Printf("Finished deleting the vra_load_balancer resource with name %s", d.Get("name"))
 	return nil
 }
+
+func resourceLoadBalancerImportList(apiClient *client.MulticloudIaaS) ([]namedResource, error) {
+	resources := make([]namedResource, 0)
+	err := listPages(func(skip int) (int, int64, error) {
+		ret := new(models.LoadBalancerResult)
+		if err := listPage(apiClient, "getLoadBalancers", "/iaas/api/load-balancers", skip, ret); err != nil {
+			return 0, 0, err
+		}
+		for _, loadBalancer := range ret.Content {
+			resources = append(resources, namedResource{ID: *loadBalancer.ID, Name: loadBalancer.Name})
+		}
+		return len(ret.Content), ret.TotalElements, nil
+	})
+	return resources, err
+}
@@ -7,13 +7,14 @@ import (
 	"strings"
 	"time"
 
-	"github.com/hashicorp/terraform/helper/resource"
 	"github.com/vmware/vra-sdk-go/pkg/client"
 	"github.com/vmware/vra-sdk-go/pkg/client/compute"
-	"github.com/vmware/vra-sdk-go/pkg/client/request"
+	"github.com/vmware/vra-sdk-go/pkg/client/network"
 	"github.com/vmware/vra-sdk-go/pkg/models"
 
+	"github.com/hashicorp/terraform/helper/customdiff"
 	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/hashicorp/terraform/helper/validation"
 )
 
 func resourceMachine() *schema.Resource {
@@ -22,40 +23,108 @@ func resourceMachine() *schema.Resource {
 		Read:   resourceMachineRead,
 		Update: resourceMachineUpdate,
 		Delete: resourceMachineDelete,
+		Importer: &schema.ResourceImporter{
+			State: resourceMachineImport,
+		},
+
+		CustomizeDiff: customdiff.All(
+			customizeDiffBootConfig,
+			customizeDiffMachineReadiness,
+		),
+
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(5 * time.Minute),
+			Update: schema.DefaultTimeout(5 * time.Minute),
+			Delete: schema.DefaultTimeout(5 * time.Minute),
+		},
 
 		Schema: map[string]*schema.Schema{
 			"flavor": &schema.Schema{
 				Type:     schema.TypeString,
 				Required: true,
 			},
+			"cpu_count": &schema.Schema{
+				Type:         schema.TypeInt,
+				Optional:     true,
+				ValidateFunc: validation.IntAtLeast(1),
+			},
+			"memory_in_mb": &schema.Schema{
+				Type:         schema.TypeInt,
+				Optional:     true,
+				ValidateFunc: validation.IntAtLeast(1),
+			},
+			"wait_for_address": &schema.Schema{
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  false,
+			},
+			"wait_for_power_on": &schema.Schema{
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  false,
+			},
+			"wait_for_tcp_port": &schema.Schema{
+				Type:         schema.TypeInt,
+				Optional:     true,
+				ValidateFunc: validation.IntBetween(1, 65535),
+			},
+			"deletion_protection": &schema.Schema{
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  false,
+			},
+			"force_delete": &schema.Schema{
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  false,
+			},
+			"replace_on_resize_failure": &schema.Schema{
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  false,
+			},
 			"image": &schema.Schema{
 				Type:     schema.TypeString,
 				Optional: true,
+				ForceNew: true,
 			},
 			"image_ref": &schema.Schema{
 				Type:     schema.TypeString,
 				Optional: true,
+				ForceNew: true,
 			},
 			"project_id": {
 				Type:     schema.TypeString,
 				Required: true,
+				ForceNew: true,
 			},
 			"power_state": &schema.Schema{
 				Type:     schema.TypeString,
+				Optional: true,
 				Computed: true,
+				ValidateFunc: validation.StringInSlice([]string{
+					machinePowerStateOn,
+					machinePowerStateOff,
+					machinePowerStateSuspended,
+				}, false),
 			},
 			"address": &schema.Schema{
 				Type:     schema.TypeString,
 				Computed: true,
 			},
-			"constraints": constraintsSchema(),
+			// The machine update API only changes the description and tags
+			"constraints": notReadBack(forceNew(constraintsSchema())),
 			"tags":        tagsSchema(),
 			"custom_properties": &schema.Schema{
-				Type:     schema.TypeMap,
-				Computed: true,
-				Optional: true,
+				Type:             schema.TypeMap,
+				Optional:         true,
+				Computed:         true,
+				ForceNew:         true,
+				DiffSuppressFunc: suppressServerCustomProperties,
 			},
-			"nics": nicsSchema(false),
+			// vRA gives a machine created without nics a default one
+			"nics": computed(forceNew(nicsSchema(false))),
+			// Only the disks in state are read back, so that removing every disk detaches them
 			"disks": &schema.Schema{
 				Type:     schema.TypeSet,
 				Optional: true,
@@ -76,19 +145,7 @@ func resourceMachine() *schema.Resource {
 					},
 				},
 			},
-			"boot_config": &schema.Schema{
-				Type:     schema.TypeSet,
-				Optional: true,
-				MaxItems: 1,
-				Elem: &schema.Resource{
-					Schema: map[string]*schema.Schema{
-						"content": {
-							Type:     schema.TypeString,
-							Optional: true,
-						},
-					},
-				},
-			},
+			"boot_config": notReadBack(forceNew(bootConfigSchema())),
 			"external_zone_id": &schema.Schema{
 				Type:     schema.TypeString,
 				Computed: true,
@@ -136,108 +193,77 @@ func resourceMachine() *schema.Resource {
 func resourceMachineCreate(d *schema.ResourceData, m interface{}) error {
 	log.Printf("Starting to create vra_machine resource")
 	apiClient := m.(*Client).apiClient
+	start := time.Now()
 
-	name := d.Get("name").(string)
-	flavor := d.Get("flavor").(string)
-	projectID := d.Get("project_id").(string)
-	constraints := expandConstraints(d.Get("constraints").(*schema.Set).List())
-	tags := expandTags(d.Get("tags").(*schema.Set).List())
-	customProperties := expandCustomProperties(d.Get("custom_properties").(map[string]interface{}))
-	nics := expandNics(d.Get("nics").(*schema.Set).List())
-	disks := expandDisks(d.Get("disks").(*schema.Set).List())
-
-	machineSpecification := models.MachineSpecification{
-		Name:             &name,
-		Flavor:           &flavor,
-		ProjectID:        &projectID,
-		Constraints:      constraints,
-		Tags:             tags,
-		CustomProperties: customProperties,
-		Nics:             nics,
-		Disks:            disks,
+	machineSpecification, err := expandMachineSpecification(d)
+	if err != nil {
+		return err
 	}
 
-	image, imageRef := "", ""
-	if v, ok := d.GetOk("image"); ok {
-		image = v.(string)
-		machineSpecification.Image = withString(image)
+	log.Printf("[DEBUG] create machine: %#v", machineSpecification)
+	createMachineCreated, err := apiClient.Compute.CreateMachine(compute.NewCreateMachineParams().WithBody(machineSpecification))
+	if err != nil {
+		return err
 	}
 
-	if v, ok := d.GetOk("image_ref"); ok {
-		imageRef = v.(string)
-		machineSpecification.ImageRef = withString(imageRef)
+	// Every step of the create gets what is left of the create timeout
+	timeout, err := remainingTimeout(d.Timeout(schema.TimeoutCreate), start, "the machine request finished")
+	if err != nil {
+		return err
 	}
-
-	if image == "" && imageRef == "" {
-		return errors.New("image or image_ref required")
+	resources, err := waitForRequestTracker(apiClient, *createMachineCreated.Payload.ID, timeout)
+	if err != nil {
+		return setPartialResourceID(d, err, trackedMachines)
 	}
 
-	if v, ok := d.GetOk("description"); ok {
-		machineSpecification.Description = v.(string)
+	id, err := createdResourceID(resources, trackedMachines, *createMachineCreated.Payload.ID)
+	if err != nil {
+		return err
 	}
 
-	if v, ok := d.GetOk("boot_config"); ok {
-		configBootConfig := v.(*schema.Set).List()[0].(map[string]interface{})
+	d.SetId(id)
 
-		bootConfig := models.MachineBootConfig{
-			Content: configBootConfig["content"].(string),
+	// The create request only takes a flavor, the CPU and memory overrides are applied with a resize
+	if d.Get("cpu_count").(int) > 0 || d.Get("memory_in_mb").(int) > 0 {
+		timeout, err := remainingTimeout(d.Timeout(schema.TimeoutCreate), start, "resizing the machine")
+		if err != nil {
+			return err
+		}
+		if err := resizeMachine(apiClient, id, d.Get("flavor").(string), d.Get("cpu_count").(int), d.Get("memory_in_mb").(int), timeout); err != nil {
+			return err
 		}
-
-		machineSpecification.BootConfig = &bootConfig
 	}
 
-	log.Printf("[DEBUG] create machine: %#v", machineSpecification)
-	createMachineCreated, err := apiClient.Compute.CreateMachine(compute.NewCreateMachineParams().WithBody(&machineSpecification))
-	if err != nil {
-		return err
+	if v, ok := d.GetOk("power_state"); ok {
+		timeout, err := remainingTimeout(d.Timeout(schema.TimeoutCreate), start, "setting the machine power state")
+		if err != nil {
+			return err
+		}
+		if err := setMachinePowerState(apiClient, id, v.(string), timeout); err != nil {
+			return err
+		}
 	}
 
-	stateChangeFunc := resource.StateChangeConf{
-		Delay:      5 * time.Second,
-		Pending:    []string{models.RequestTrackerStatusINPROGRESS},
-		Refresh:    machineStateRefreshFunc(*apiClient, *createMachineCreated.Payload.ID),
-		Target:     []string{models.RequestTrackerStatusFINISHED},
-		Timeout:    5 * time.Minute,
-		MinTimeout: 5 * time.Second,
+	readiness := machineReadiness{
+		Address:   d.Get("wait_for_address").(bool),
+		PoweredOn: d.Get("wait_for_power_on").(bool),
+		TCPPort:   d.Get("wait_for_tcp_port").(int),
 	}
-
-	resourceIds, err := stateChangeFunc.WaitForState()
-	if err != nil {
-		return err
+	if !readiness.empty() {
+		timeout, err := remainingTimeout(d.Timeout(schema.TimeoutCreate), start, "the machine was ready")
+		if err != nil {
+			return err
+		}
+		if err := waitForMachineReady(apiClient, id, readiness, timeout); err != nil {
+			return err
+		}
 	}
 
-	machineIds := resourceIds.([]string)
-	d.SetId(machineIds[0])
 	log.Printf("Finished to create vra_machine resource with name %s", d.Get("name"))
 
 	return resourceMachineRead(d, m)
 }
 
-func machineStateRefreshFunc(apiClient client.MulticloudIaaS, id string) resource.StateRefreshFunc {
-	return func() (interface{}, string, error) {
-		ret, err := apiClient.Request.GetRequestTracker(request.NewGetRequestTrackerParams().WithID(id))
-		if err != nil {
-			return "", models.RequestTrackerStatusFAILED, err
-		}
-
-		status := ret.Payload.Status
-		switch *status {
-		case models.RequestTrackerStatusFAILED:
-			return []string{""}, *status, fmt.Errorf(ret.Payload.Message)
-		case models.RequestTrackerStatusINPROGRESS:
-			return [...]string{id}, *status, nil
-		case models.RequestTrackerStatusFINISHED:
-			machineIds := make([]string, len(ret.Payload.Resources))
-			for i, r := range ret.Payload.Resources {
-				machineIds[i] = strings.TrimPrefix(r, "/iaas/api/machines/")
-			}
-			return machineIds, *status, nil
-		default:
-			return [...]string{id}, ret.Payload.Message, fmt.Errorf("machineStateRefreshFunc: unknown status %v", *status)
-		}
-	}
-}
-
 func resourceMachineRead(d *schema.ResourceData, m interface{}) error {
 	log.Printf("Reading the vra_machine resource with name %s", d.Get("name"))
 	apiClient := m.(*Client).apiClient
@@ -247,8 +273,7 @@ func resourceMachineRead(d *schema.ResourceData, m interface{}) error {
 	if err != nil {
 		switch err.(type) {
 		case *compute.GetMachineNotFound:
-			d.SetId("")
-			return nil
+			return removeDeletedResource(d, "vra_machine")
 		}
 		return err
 	}
@@ -256,7 +281,7 @@ func resourceMachineRead(d *schema.ResourceData, m interface{}) error {
 	machine := *resp.Payload
 	d.Set("name", machine.Name)
 	d.Set("description", machine.Description)
-	d.Set("power_state", machine.PowerState)
+	d.Set("power_state", flattenMachinePowerState(machine.PowerState))
 	d.Set("address", machine.Address)
 	d.Set("project_id", machine.ProjectID)
 	d.Set("external_zone_id", machine.ExternalZoneID)
@@ -268,10 +293,32 @@ func resourceMachineRead(d *schema.ResourceData, m interface{}) error {
 	d.Set("organization_id", machine.OrganizationID)
 	d.Set("custom_properties", machine.CustomProperties)
 
+	setMachineImageAndFlavor(d, machine.CustomProperties)
+
+	if err := setConstraints(d, apiClient, "/iaas/api/machines/{id}", id); err != nil {
+		return err
+	}
+
+	nics, err := getMachineNetworkInterfaces(apiClient, id, machine.Links)
+	if err != nil {
+		return fmt.Errorf("error reading machine network interfaces - error: %v", err)
+	}
+	if err := d.Set("nics", flattenNics(nics, d.Get("nics").(*schema.Set).List())); err != nil {
+		return fmt.Errorf("error setting machine nics - error: %v", err)
+	}
+
 	if err := d.Set("tags", flattenTags(machine.Tags)); err != nil {
 		return fmt.Errorf("error setting machine tags - error: %v", err)
 	}
 
+	blockDeviceIDs, err := getMachineDiskIDs(apiClient, id)
+	if err != nil {
+		return fmt.Errorf("error reading machine disks - error: %v", err)
+	}
+	if err := d.Set("disks", flattenMachineDisks(blockDeviceIDs, d.Get("disks").(*schema.Set).List())); err != nil {
+		return fmt.Errorf("error setting machine disks - error: %v", err)
+	}
+
 	if err := d.Set("links", flattenLinks(machine.Links)); err != nil {
 		return fmt.Errorf("error setting machine links - error: %#v", err)
 	}
@@ -280,11 +327,73 @@ func resourceMachineRead(d *schema.ResourceData, m interface{}) error {
 	return nil
 }
 
+// setMachineImageAndFlavor reads back the image and flavor a machine was provisioned
+// from, which it only reports in its custom properties. The image is read back the
+// way it is referenced, by name unless only image_ref is set.
+func setMachineImageAndFlavor(d *schema.ResourceData, customProperties map[string]string) {
+	if d.Get("image_ref").(string) != "" && d.Get("image").(string) == "" {
+		if v, ok := customProperties["imageRef"]; ok && v != "" {
+			d.Set("image_ref", v)
+		}
+	} else if v, ok := customProperties["image"]; ok && v != "" {
+		d.Set("image", v)
+	}
+	if v, ok := customProperties["flavor"]; ok && v != "" {
+		d.Set("flavor", v)
+	}
+}
+
 func resourceMachineUpdate(d *schema.ResourceData, m interface{}) error {
 	log.Printf("Starting to update the vra_machine resource with name %s", d.Get("name"))
 	apiClient := m.(*Client).apiClient
 
 	id := d.Id()
+	if d.HasChange("flavor") || d.HasChange("cpu_count") || d.HasChange("memory_in_mb") {
+		// Keep the previous size in state if the resize fails
+		d.Partial(true)
+		err := resizeMachine(apiClient, id, d.Get("flavor").(string), d.Get("cpu_count").(int), d.Get("memory_in_mb").(int), d.Timeout(schema.TimeoutUpdate))
+		if err != nil {
+			if !d.Get("replace_on_resize_failure").(bool) {
+				return err
+			}
+
+			log.Printf("[WARN] %v, replacing the vra_machine resource with name %s", err, d.Get("name"))
+			if err := checkDeletionProtection(d, "vra_machine"); err != nil {
+				return err
+			}
+			err = replaceMachine(d, func() error {
+				return resourceMachineCreate(d, m)
+			}, func(id string) error {
+				return deleteMachine(d, apiClient, id)
+			})
+			if err != nil {
+				return err
+			}
+			d.Partial(false)
+			return nil
+		}
+		d.Partial(false)
+	}
+
+	if d.HasChange("disks") {
+		oldDisks, newDisks := d.GetChange("disks")
+		detach, attach := machineDiskChanges(oldDisks.(*schema.Set), newDisks.(*schema.Set))
+
+		// Keep the disks that did not change in state if an attach or detach fails
+		d.Partial(true)
+		for _, blockDeviceID := range detach {
+			if err := detachMachineDisk(apiClient, id, blockDeviceID, d.Timeout(schema.TimeoutUpdate)); err != nil {
+				return err
+			}
+		}
+		for _, spec := range attach {
+			if err := attachMachineDisk(apiClient, id, spec, d.Timeout(schema.TimeoutUpdate)); err != nil {
+				return err
+			}
+		}
+		d.Partial(false)
+	}
+
 	description := d.Get("description").(string)
 	tags := expandTags(d.Get("tags").(*schema.Set).List())
 
@@ -299,31 +408,59 @@ func resourceMachineUpdate(d *schema.ResourceData, m interface{}) error {
 		return err
 	}
 
+	if d.HasChange("power_state") {
+		if v, ok := d.GetOk("power_state"); ok {
+			if err := setMachinePowerState(apiClient, id, v.(string), d.Timeout(schema.TimeoutUpdate)); err != nil {
+				return err
+			}
+		}
+	}
+
 	log.Printf("Finished updating the vra_machine resource with name %s", d.Get("name"))
 	return resourceMachineRead(d, m)
 }
 
-func resourceMachineDelete(d *schema.ResourceData, m interface{}) error {
-	log.Printf("Starting to delete the vra_machine resource with name %s", d.Get("name"))
-	apiClient := m.(*Client).apiClient
+// replaceMachine replaces the machine of d with a new one. The replacement is
+// created first and the previous machine only deleted once it exists, a failed
+// create keeps the previous machine in state.
+func replaceMachine(d *schema.ResourceData, create func() error, remove func(id string) error) error {
+	oldID := d.Id()
+	if err := create(); err != nil {
+		if d.Id() != "" && d.Id() != oldID {
+			log.Printf("[WARN] the failed replacement of machine %s left machine %s behind", oldID, d.Id())
+			err = fmt.Errorf("%v, machine %s was left behind", err, d.Id())
+		}
+		d.SetId(oldID)
+		return fmt.Errorf("error creating the replacement of machine %s: %v", oldID, err)
+	}
 
-	id := d.Id()
-	deleteMachine, err := apiClient.Compute.DeleteMachine(compute.NewDeleteMachineParams().WithID(id))
+	log.Printf("[WARN] machine %s replaced by machine %s, deleting it", oldID, d.Id())
+	if err := remove(oldID); err != nil {
+		return fmt.Errorf("machine %s replaced by machine %s could not be deleted: %v", oldID, d.Id(), err)
+	}
+	return nil
+}
+
+// deleteMachine deletes the given machine and waits for it on the request tracker
+func deleteMachine(d *schema.ResourceData, apiClient *client.MulticloudIaaS, id string) error {
+	deleteMachineAccepted, err := apiClient.Compute.DeleteMachine(compute.NewDeleteMachineParams().WithID(id).WithContext(deleteContext(d)))
 	if err != nil {
 		return err
 	}
 
-	stateChangeFunc := resource.StateChangeConf{
-		Delay:      5 * time.Second,
-		Pending:    []string{models.RequestTrackerStatusINPROGRESS},
-		Refresh:    machineStateRefreshFunc(*apiClient, *deleteMachine.Payload.ID),
-		Target:     []string{models.RequestTrackerStatusFINISHED},
-		Timeout:    5 * time.Minute,
-		MinTimeout: 5 * time.Second,
+	_, err = waitForRequestTracker(apiClient, *deleteMachineAccepted.Payload.ID, d.Timeout(schema.TimeoutDelete))
+	return err
+}
+
+func resourceMachineDelete(d *schema.ResourceData, m interface{}) error {
+	log.Printf("Starting to delete the vra_machine resource with name %s", d.Get("name"))
+	apiClient := m.(*Client).apiClient
+
+	if err := checkDeletionProtection(d, "vra_machine"); err != nil {
+		return err
 	}
 
-	_, err = stateChangeFunc.WaitForState()
-	if err != nil {
+	if err := deleteMachine(d, apiClient, d.Id()); err != nil {
 		return err
 	}
 
@@ -332,6 +469,61 @@ func resourceMachineDelete(d *schema.ResourceData, m interface{}) error {
 	return nil
 }
 
+// expandMachineSpecification returns the specification of the machine, or machines
+// of a group, to create from the arguments shared by vra_machine and vra_machine_group
+func expandMachineSpecification(d *schema.ResourceData) (*models.MachineSpecification, error) {
+	name := d.Get("name").(string)
+	flavor := d.Get("flavor").(string)
+	projectID := d.Get("project_id").(string)
+	constraints := expandConstraints(d.Get("constraints").(*schema.Set).List())
+	tags := expandTags(d.Get("tags").(*schema.Set).List())
+	customProperties := expandCustomProperties(d.Get("custom_properties").(map[string]interface{}))
+	nics := expandNics(d.Get("nics").(*schema.Set).List())
+
+	machineSpecification := models.MachineSpecification{
+		Name:             &name,
+		Flavor:           &flavor,
+		ProjectID:        &projectID,
+		Constraints:      constraints,
+		Tags:             tags,
+		CustomProperties: customProperties,
+		Nics:             nics,
+		Disks:            make([]*models.DiskAttachmentSpecification, 0),
+	}
+
+	image, imageRef := "", ""
+	if v, ok := d.GetOk("image"); ok {
+		image = v.(string)
+		machineSpecification.Image = withString(image)
+	}
+
+	if v, ok := d.GetOk("image_ref"); ok {
+		imageRef = v.(string)
+		machineSpecification.ImageRef = withString(imageRef)
+	}
+
+	if image == "" && imageRef == "" {
+		return nil, errors.New("image or image_ref required")
+	}
+
+	if v, ok := d.GetOk("description"); ok {
+		machineSpecification.Description = v.(string)
+	}
+
+	bootConfig, err := expandBootConfig(d.Get("boot_config").(*schema.Set).List())
+	if err != nil {
+		return nil, err
+	}
+	machineSpecification.BootConfig = bootConfig
+
+	// A machine group has no disks, they are attached to a single machine
+	if v, ok := d.GetOk("disks"); ok {
+		machineSpecification.Disks = expandDisks(v.(*schema.Set).List())
+	}
+
+	return &machineSpecification, nil
+}
+
 func expandDisks(configDisks []interface{}) []*models.DiskAttachmentSpecification {
 	disks := make([]*models.DiskAttachmentSpecification, 0, len(configDisks))
 
@@ -355,3 +547,55 @@ func expandDisks(configDisks []interface{}) []*models.DiskAttachmentSpecificatio
 
 	return disks
 }
+
+// resourceMachineImport imports a machine by ID or name with every block device
+// attached to it, which Read then keeps in disks while they stay attached
+func resourceMachineImport(d *schema.ResourceData, m interface{}) ([]*schema.ResourceData, error) {
+	ret, err := importByIDOrName(resourceMachineImportList).State(d, m)
+	if err != nil {
+		return nil, err
+	}
+
+	blockDeviceIDs, err := getMachineDiskIDs(m.(*Client).apiClient, d.Id())
+	if err != nil {
+		return nil, fmt.Errorf("error reading machine disks - error: %v", err)
+	}
+
+	disks := make([]interface{}, 0, len(blockDeviceIDs))
+	for _, id := range blockDeviceIDs {
+		disks = append(disks, map[string]interface{}{"block_device_id": id})
+	}
+	if err := d.Set("disks", disks); err != nil {
+		return nil, fmt.Errorf("error setting machine disks - error: %v", err)
+	}
+	return ret, nil
+}
+
+func resourceMachineImportList(apiClient *client.MulticloudIaaS) ([]namedResource, error) {
+	resources := make([]namedResource, 0)
+	err := listPages(func(skip int) (int, int64, error) {
+		ret := new(models.MachineResult)
+		if err := listPage(apiClient, "getMachines", "/iaas/api/machines", skip, ret); err != nil {
+			return 0, 0, err
+		}
+		for _, machine := range ret.Content {
+			resources = append(resources, namedResource{ID: *machine.ID, Name: machine.Name})
+		}
+		return len(ret.Content), ret.TotalElements, nil
+	})
+	return resources, err
+}
+
+// getMachineNetworkInterfaces returns the network interfaces linked from a machine
+func getMachineNetworkInterfaces(apiClient *client.MulticloudIaaS, machineID string, links map[string]models.Href) ([]*models.NetworkInterface, error) {
+	nicIDs := linkIDs(links, "network-interfaces")
+	nics := make([]*models.NetworkInterface, 0, len(nicIDs))
+	for _, nicID := range nicIDs {
+		ret, err := apiClient.Network.GetMachineNetworkInterface(network.NewGetMachineNetworkInterfaceParams().WithID(machineID).WithId1(nicID))
+		if err != nil {
+			return nil, err
+		}
+		nics = append(nics, ret.Payload)
+	}
+	return nics, nil
+}
@@ -0,0 +1,91 @@
+package vra
+
+import (
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/vmware/vra-sdk-go/pkg/client/compute"
+
+	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/hashicorp/terraform/helper/validation"
+)
+
+// machineActions maps the action argument of vra_machine_action to the machine operations
+var machineActions = map[string]string{
+	"reboot":         machineOperationReboot,
+	"reset":          machineOperationReset,
+	"shutdown-guest": machineOperationShutdown,
+	"restart-guest":  machineOperationRestart,
+}
+
+func resourceMachineAction() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceMachineActionCreate,
+		Read:   resourceMachineActionRead,
+		Delete: resourceMachineActionDelete,
+
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(5 * time.Minute),
+		},
+
+		Schema: map[string]*schema.Schema{
+			"machine_id": &schema.Schema{
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+			"action": &schema.Schema{
+				Type:         schema.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: validation.StringInSlice([]string{"reboot", "reset", "shutdown-guest", "restart-guest"}, false),
+			},
+			"triggers": &schema.Schema{
+				Type:     schema.TypeMap,
+				Optional: true,
+				ForceNew: true,
+			},
+		},
+	}
+}
+
+func resourceMachineActionCreate(d *schema.ResourceData, m interface{}) error {
+	apiClient := m.(*Client).apiClient
+
+	machineID := d.Get("machine_id").(string)
+	action := d.Get("action").(string)
+	log.Printf("Starting to run %s on machine %s", action, machineID)
+
+	if _, err := runMachineOperation(apiClient, machineID, machineActions[action], d.Timeout(schema.TimeoutCreate)); err != nil {
+		return err
+	}
+
+	d.SetId(resource.UniqueId())
+	log.Printf("Finished to run %s on machine %s", action, machineID)
+
+	return resourceMachineActionRead(d, m)
+}
+
+func resourceMachineActionRead(d *schema.ResourceData, m interface{}) error {
+	apiClient := m.(*Client).apiClient
+
+	// The action is kept as long as the machine it ran on exists
+	_, err := apiClient.Compute.GetMachine(compute.NewGetMachineParams().WithID(d.Get("machine_id").(string)))
+	if err != nil {
+		switch err.(type) {
+		case *compute.GetMachineNotFound:
+			return removeDeletedResource(d, "vra_machine_action")
+		}
+		return fmt.Errorf("error reading machine %s: %v", d.Get("machine_id"), err)
+	}
+
+	return nil
+}
+
+func resourceMachineActionDelete(d *schema.ResourceData, m interface{}) error {
+	// An action that ran cannot be undone, it is only removed from the state
+	d.SetId("")
+	return nil
+}
@@ -0,0 +1,66 @@
+package vra
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform/helper/acctest"
+	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/hashicorp/terraform/terraform"
+)
+
+func TestAccVRAMachineAction_Reboot(t *testing.T) {
+	rInt := acctest.RandInt()
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheckMachine(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckVRAMachineDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccCheckVRAMachineActionConfig(rInt, "patch-1"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					testAccCheckVRAMachineActionExists("vra_machine_action.reboot"),
+					resource.TestCheckResourceAttr(
+						"vra_machine_action.reboot", "action", "reboot"),
+				),
+			},
+			{
+				Config: testAccCheckVRAMachineActionConfig(rInt, "patch-2"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					testAccCheckVRAMachineActionExists("vra_machine_action.reboot"),
+					resource.TestCheckResourceAttr(
+						"vra_machine_action.reboot", "triggers.patch", "patch-2"),
+				),
+			},
+		},
+	})
+}
+
+func testAccCheckVRAMachineActionExists(n string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[n]
+		if !ok {
+			return fmt.Errorf("not found: %s", n)
+		}
+
+		if rs.Primary.ID == "" {
+			return fmt.Errorf("no machine action ID is set")
+		}
+
+		return nil
+	}
+}
+
+func testAccCheckVRAMachineActionConfig(rInt int, patch string) string {
+	return testAccCheckVRAMachineConfig(rInt) + fmt.Sprintf(`
+
+resource "vra_machine_action" "reboot" {
+	machine_id = vra_machine.my_machine.id
+	action     = "reboot"
+
+	triggers = {
+	  patch = "%s"
+	}
+}`, patch)
+}
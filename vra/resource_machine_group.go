@@ -0,0 +1,350 @@
+package vra
+
+import (
+	"fmt"
+	"log"
+	"strings"
+	"time"
+
+	"github.com/vmware/vra-sdk-go/pkg/client"
+	"github.com/vmware/vra-sdk-go/pkg/client/compute"
+	"github.com/vmware/vra-sdk-go/pkg/models"
+
+	"github.com/hashicorp/terraform/helper/customdiff"
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/hashicorp/terraform/helper/validation"
+)
+
+func resourceMachineGroup() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceMachineGroupCreate,
+		Read:   resourceMachineGroupRead,
+		Update: resourceMachineGroupUpdate,
+		Delete: resourceMachineGroupDelete,
+		Importer: &schema.ResourceImporter{
+			State: resourceMachineGroupImport,
+		},
+
+		CustomizeDiff: customdiff.All(
+			customizeDiffBootConfig,
+			// Scaling adds or removes machines, which are only known once applied
+			customdiff.ComputedIf("machine_ids", machineCountChanged),
+			customdiff.ComputedIf("machines", machineCountChanged),
+		),
+
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(5 * time.Minute),
+			Update: schema.DefaultTimeout(5 * time.Minute),
+			Delete: schema.DefaultTimeout(5 * time.Minute),
+		},
+
+		Schema: map[string]*schema.Schema{
+			"name": &schema.Schema{
+				Type:             schema.TypeString,
+				Required:         true,
+				ForceNew:         true,
+				DiffSuppressFunc: suppressNameSuffix,
+			},
+			"machine_count": &schema.Schema{
+				Type:         schema.TypeInt,
+				Required:     true,
+				ValidateFunc: validation.IntAtLeast(1),
+			},
+			"flavor": &schema.Schema{
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+			"image": &schema.Schema{
+				Type:     schema.TypeString,
+				Optional: true,
+				ForceNew: true,
+			},
+			"image_ref": &schema.Schema{
+				Type:     schema.TypeString,
+				Optional: true,
+				ForceNew: true,
+			},
+			"project_id": &schema.Schema{
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+			"description": &schema.Schema{
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+			"constraints": notReadBack(forceNew(constraintsSchema())),
+			"tags":        tagsSchema(),
+			"custom_properties": &schema.Schema{
+				Type:             schema.TypeMap,
+				Optional:         true,
+				Computed:         true,
+				ForceNew:         true,
+				DiffSuppressFunc: suppressServerCustomProperties,
+			},
+			"nics":        computed(forceNew(nicsSchema(false))),
+			"boot_config": notReadBack(forceNew(bootConfigSchema())),
+			"machine_ids": &schema.Schema{
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+			"machines": &schema.Schema{
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"id": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"name": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"address": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"power_state": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func resourceMachineGroupCreate(d *schema.ResourceData, m interface{}) error {
+	log.Printf("Starting to create vra_machine_group resource")
+	apiClient := m.(*Client).apiClient
+
+	requestID, machineIDs, err := createMachineGroupMembers(apiClient, d, d.Get("machine_count").(int), d.Timeout(schema.TimeoutCreate))
+	if requestID != "" && len(machineIDs) > 0 {
+		// Machines created by a failed request are kept so that they are destroyed with the group
+		d.SetId(machineGroupID(machineIDs))
+		d.Set("machine_ids", machineIDs)
+	}
+	if err != nil {
+		if d.Id() != "" {
+			return fmt.Errorf("%v, the %d machines created were saved in state and will be replaced on the next apply", err, len(machineIDs))
+		}
+		return err
+	}
+
+	log.Printf("Finished to create vra_machine_group resource with name %s and machines %v", d.Get("name"), machineIDs)
+	return resourceMachineGroupRead(d, m)
+}
+
+func resourceMachineGroupRead(d *schema.ResourceData, m interface{}) error {
+	log.Printf("Reading the vra_machine_group resource with name %s", d.Get("name"))
+	apiClient := m.(*Client).apiClient
+
+	var first *models.Machine
+	machineIDs := make([]string, 0)
+	machines := make([]map[string]interface{}, 0)
+	for _, id := range expandStringList(d.Get("machine_ids").([]interface{})) {
+		resp, err := apiClient.Compute.GetMachine(compute.NewGetMachineParams().WithID(id))
+		if err != nil {
+			switch err.(type) {
+			case *compute.GetMachineNotFound:
+				// A member deleted outside Terraform shows up as a scale up in the plan
+				log.Printf("[WARN] machine %s of the vra_machine_group resource with name %s no longer exists", id, d.Get("name"))
+				continue
+			}
+			return err
+		}
+
+		machine := resp.Payload
+		if first == nil {
+			first = machine
+		}
+		machineIDs = append(machineIDs, id)
+		machines = append(machines, map[string]interface{}{
+			"id":          id,
+			"name":        machine.Name,
+			"address":     machine.Address,
+			"power_state": flattenMachinePowerState(machine.PowerState),
+		})
+	}
+
+	if len(machineIDs) == 0 {
+		return removeDeletedResource(d, "vra_machine_group")
+	}
+
+	d.Set("machine_ids", machineIDs)
+	d.Set("machine_count", len(machineIDs))
+	if err := d.Set("machines", machines); err != nil {
+		return fmt.Errorf("error setting machine group machines - error: %v", err)
+	}
+
+	// The machines of a group share these, they are read from the first one
+	d.Set("name", first.Name)
+	d.Set("project_id", first.ProjectID)
+	d.Set("description", first.Description)
+	d.Set("custom_properties", first.CustomProperties)
+	if err := d.Set("tags", flattenTags(first.Tags)); err != nil {
+		return fmt.Errorf("error setting machine group tags - error: %v", err)
+	}
+	setMachineImageAndFlavor(d, first.CustomProperties)
+	if err := setConstraints(d, apiClient, "/iaas/api/machines/{id}", machineIDs[0]); err != nil {
+		return err
+	}
+
+	nics, err := getMachineNetworkInterfaces(apiClient, machineIDs[0], first.Links)
+	if err != nil {
+		return fmt.Errorf("error reading machine group network interfaces - error: %v", err)
+	}
+	if err := d.Set("nics", flattenNics(nics, d.Get("nics").(*schema.Set).List())); err != nil {
+		return fmt.Errorf("error setting machine group nics - error: %v", err)
+	}
+
+	log.Printf("Finished reading the vra_machine_group resource with name %s", d.Get("name"))
+	return nil
+}
+
+func resourceMachineGroupUpdate(d *schema.ResourceData, m interface{}) error {
+	log.Printf("Starting to update the vra_machine_group resource with name %s", d.Get("name"))
+	apiClient := m.(*Client).apiClient
+
+	machineIDs := expandStringList(d.Get("machine_ids").([]interface{}))
+	count := d.Get("machine_count").(int)
+
+	if count > len(machineIDs) {
+		_, created, err := createMachineGroupMembers(apiClient, d, count-len(machineIDs), d.Timeout(schema.TimeoutUpdate))
+		machineIDs = append(machineIDs, created...)
+		d.Set("machine_ids", machineIDs)
+		if err != nil {
+			return err
+		}
+	}
+
+	// Scale down removes the most recently added machines first
+	for len(machineIDs) > count {
+		last := machineIDs[len(machineIDs)-1]
+		if err := deleteMachineGroupMember(apiClient, last, d.Timeout(schema.TimeoutUpdate)); err != nil {
+			return err
+		}
+		machineIDs = machineIDs[:len(machineIDs)-1]
+		d.Set("machine_ids", machineIDs)
+	}
+
+	if d.HasChange("description") || d.HasChange("tags") {
+		updateMachineSpecification := models.UpdateMachineSpecification{
+			Description: d.Get("description").(string),
+			Tags:        expandTags(d.Get("tags").(*schema.Set).List()),
+		}
+
+		for _, id := range machineIDs {
+			log.Printf("[DEBUG] update machine %s: %#v", id, updateMachineSpecification)
+			_, err := apiClient.Compute.UpdateMachine(compute.NewUpdateMachineParams().WithID(id).WithBody(&updateMachineSpecification))
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	log.Printf("Finished updating the vra_machine_group resource with name %s", d.Get("name"))
+	return resourceMachineGroupRead(d, m)
+}
+
+func resourceMachineGroupDelete(d *schema.ResourceData, m interface{}) error {
+	log.Printf("Starting to delete the vra_machine_group resource with name %s", d.Get("name"))
+	apiClient := m.(*Client).apiClient
+
+	machineIDs := expandStringList(d.Get("machine_ids").([]interface{}))
+	for len(machineIDs) > 0 {
+		last := machineIDs[len(machineIDs)-1]
+		if err := deleteMachineGroupMember(apiClient, last, d.Timeout(schema.TimeoutDelete)); err != nil {
+			return err
+		}
+		machineIDs = machineIDs[:len(machineIDs)-1]
+		d.Set("machine_ids", machineIDs)
+	}
+
+	d.SetId("")
+	log.Printf("Finished deleting the vra_machine_group resource with name %s", d.Get("name"))
+	return nil
+}
+
+// resourceMachineGroupImport imports a group from the comma separated IDs of its machines
+func resourceMachineGroupImport(d *schema.ResourceData, m interface{}) ([]*schema.ResourceData, error) {
+	machineIDs := make([]string, 0)
+	for _, id := range strings.Split(d.Id(), ",") {
+		id = strings.TrimSpace(id)
+		if id == "" {
+			return nil, fmt.Errorf("expected an ID of the form <machine_id>,<machine_id>,..., got %q", d.Id())
+		}
+		machineIDs = append(machineIDs, id)
+	}
+
+	d.SetId(machineGroupID(machineIDs))
+	d.Set("machine_ids", machineIDs)
+	return []*schema.ResourceData{d}, nil
+}
+
+// machineCountChanged reports whether the group is scaled up or down
+func machineCountChanged(d *schema.ResourceDiff, m interface{}) bool {
+	return d.Id() != "" && d.HasChange("machine_count")
+}
+
+// machineGroupID returns the ID of a group, the ID of its first machine, which
+// stays the same as machines are added and removed
+func machineGroupID(machineIDs []string) string {
+	return machineIDs[0]
+}
+
+// createMachineGroupMembers submits one request for count machines of the group and
+// returns the request ID and the machines it created, even when the request failed
+func createMachineGroupMembers(apiClient *client.MulticloudIaaS, d *schema.ResourceData, count int, timeout time.Duration) (string, []string, error) {
+	machineSpecification, err := expandMachineSpecification(d)
+	if err != nil {
+		return "", nil, err
+	}
+	machineSpecification.MachineCount = int32(count)
+
+	log.Printf("[DEBUG] create %d machines: %#v", count, machineSpecification)
+	createMachineCreated, err := apiClient.Compute.CreateMachine(compute.NewCreateMachineParams().WithBody(machineSpecification))
+	if err != nil {
+		return "", nil, err
+	}
+	requestID := *createMachineCreated.Payload.ID
+
+	resources, err := waitForRequestTracker(apiClient, requestID, timeout)
+	if err != nil {
+		if trackerErr, ok := err.(*requestTrackerError); ok {
+			return requestID, trackedResourceIDs(trackerErr.Resources, trackedMachines), err
+		}
+		return requestID, nil, err
+	}
+
+	machineIDs := trackedResourceIDs(resources, trackedMachines)
+	if len(machineIDs) != count {
+		return requestID, machineIDs, fmt.Errorf("request %s created %d machines instead of %d: %s", requestID, len(machineIDs), count, strings.Join(machineIDs, ", "))
+	}
+	return requestID, machineIDs, nil
+}
+
+// deleteMachineGroupMember deletes a machine of a group, a machine already gone counts as deleted
+func deleteMachineGroupMember(apiClient *client.MulticloudIaaS, id string, timeout time.Duration) error {
+	if _, err := apiClient.Compute.GetMachine(compute.NewGetMachineParams().WithID(id)); err != nil {
+		switch err.(type) {
+		case *compute.GetMachineNotFound:
+			return nil
+		}
+		return err
+	}
+
+	deleteMachine, err := apiClient.Compute.DeleteMachine(compute.NewDeleteMachineParams().WithID(id))
+	if err != nil {
+		return err
+	}
+
+	_, err = waitForRequestTracker(apiClient, *deleteMachine.Payload.ID, timeout)
+	return err
+}
@@ -0,0 +1,191 @@
+package vra
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform/config"
+	"github.com/hashicorp/terraform/helper/acctest"
+	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/hashicorp/terraform/terraform"
+	"github.com/vmware/vra-sdk-go/pkg/client/compute"
+)
+
+func TestAccVRAMachineGroup_Scale(t *testing.T) {
+	rInt := acctest.RandInt()
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheckMachine(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckVRAMachineGroupDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccCheckVRAMachineGroupConfig(rInt, 2),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr(
+						"vra_machine_group.web", "machine_ids.#", "2"),
+					resource.TestCheckResourceAttr(
+						"vra_machine_group.web", "machines.#", "2"),
+				),
+			},
+			{
+				Config: testAccCheckVRAMachineGroupConfig(rInt, 3),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr(
+						"vra_machine_group.web", "machine_ids.#", "3"),
+				),
+			},
+			{
+				Config: testAccCheckVRAMachineGroupConfig(rInt, 1),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr(
+						"vra_machine_group.web", "machine_ids.#", "1"),
+				),
+			},
+			{
+				ResourceName:      "vra_machine_group.web",
+				ImportState:       true,
+				ImportStateIdFunc: testAccVRAMachineGroupImportID("vra_machine_group.web"),
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func testAccVRAMachineGroupImportID(n string) resource.ImportStateIdFunc {
+	return func(s *terraform.State) (string, error) {
+		rs, ok := s.RootModule().Resources[n]
+		if !ok {
+			return "", fmt.Errorf("not found: %s", n)
+		}
+
+		count, err := strconv.Atoi(rs.Primary.Attributes["machine_ids.#"])
+		if err != nil {
+			return "", err
+		}
+
+		machineIDs := make([]string, 0, count)
+		for i := 0; i < count; i++ {
+			machineIDs = append(machineIDs, rs.Primary.Attributes[fmt.Sprintf("machine_ids.%d", i)])
+		}
+		return strings.Join(machineIDs, ","), nil
+	}
+}
+
+func TestResourceMachineGroupImport(t *testing.T) {
+	d := schema.TestResourceDataRaw(t, resourceMachineGroup().Schema, map[string]interface{}{})
+	d.SetId("m-1, m-2")
+
+	if _, err := resourceMachineGroupImport(d, nil); err != nil {
+		t.Fatalf("err: %s", err)
+	}
+	if d.Id() != "m-1" {
+		t.Fatalf("expected the group to take the ID of its first machine, got %s", d.Id())
+	}
+	if ids := expandStringList(d.Get("machine_ids").([]interface{})); len(ids) != 2 || ids[1] != "m-2" {
+		t.Fatalf("expected machines m-1 and m-2, got %v", ids)
+	}
+
+	d.SetId("m-1,,m-2")
+	if _, err := resourceMachineGroupImport(d, nil); err == nil {
+		t.Fatal("expected an ID with an empty machine ID to be rejected")
+	}
+}
+
+func TestResourceMachineGroupDiff(t *testing.T) {
+	// An imported group of two machines, whose first machine vRA named from the group
+	state := &terraform.InstanceState{
+		ID: "m-1",
+		Attributes: map[string]string{
+			"id":                       "m-1",
+			"name":                     "web-mcm1-1",
+			"machine_count":            "2",
+			"project_id":               "p-1",
+			"flavor":                   "small",
+			"image":                    "ubuntu",
+			"nics.#":                   "0",
+			"custom_properties.%":      "1",
+			"custom_properties.vcUuid": "5030c4b0",
+			"machine_ids.#":            "2",
+			"machine_ids.0":            "m-1",
+			"machine_ids.1":            "m-2",
+		},
+	}
+
+	resourceConfig := func(count int) *terraform.ResourceConfig {
+		raw, err := config.NewRawConfig(map[string]interface{}{
+			"name":          "web",
+			"machine_count": count,
+			"project_id":    "p-1",
+			"flavor":        "small",
+			"image":         "ubuntu",
+			"constraints":   []interface{}{map[string]interface{}{"mandatory": true, "expression": "env:dev"}},
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+		return terraform.NewResourceConfig(raw)
+	}
+
+	diff, err := resourceMachineGroup().Diff(state, resourceConfig(2), nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if diff != nil && (diff.RequiresNew() || diff.Attributes["machine_ids.#"] != nil) {
+		t.Fatalf("expected no change for the imported group, got %v", diff)
+	}
+
+	diff, err = resourceMachineGroup().Diff(state, resourceConfig(3), nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if diff == nil || diff.RequiresNew() {
+		t.Fatalf("expected the group to be scaled in place, got %v", diff)
+	}
+	for _, key := range []string{"machine_ids.#", "machines.#"} {
+		if diff.Attributes[key] == nil || !diff.Attributes[key].NewComputed {
+			t.Fatalf("expected %s to be known after the scale up, got %v", key, diff)
+		}
+	}
+}
+
+func testAccCheckVRAMachineGroupDestroy(s *terraform.State) error {
+	apiClient := testAccProviderVRA.Meta().(*Client).apiClient
+
+	for _, rs := range s.RootModule().Resources {
+		if rs.Type != "vra_machine_group" {
+			continue
+		}
+
+		for key, id := range rs.Primary.Attributes {
+			if key == "machine_ids.#" || len(key) < len("machine_ids.") || key[:len("machine_ids.")] != "machine_ids." {
+				continue
+			}
+			_, err := apiClient.Compute.GetMachine(compute.NewGetMachineParams().WithID(id))
+			if err == nil {
+				return fmt.Errorf("machine %s of the vra_machine_group still exists", id)
+			}
+		}
+	}
+
+	return testAccCheckVRAMachineDestroy(s)
+}
+
+func testAccCheckVRAMachineGroupConfig(rInt, count int) string {
+	return testAccCheckVRAMachine(rInt) + fmt.Sprintf(`
+resource "vra_machine_group" "web" {
+	name          = "my-machine-group-%d"
+	machine_count = %d
+	project_id    = vra_project.my-project.id
+	image         = "ubuntu"
+	flavor        = "small"
+
+	constraints {
+	  mandatory  = true
+	  expression = "env:dev"
+	}
+}`, rInt, count)
+}
@@ -0,0 +1,245 @@
+package vra
+
+import (
+	"fmt"
+	"log"
+	"strings"
+	"time"
+
+	"github.com/vmware/vra-sdk-go/pkg/client"
+	"github.com/vmware/vra-sdk-go/pkg/client/compute"
+	"github.com/vmware/vra-sdk-go/pkg/models"
+
+	"github.com/hashicorp/terraform/helper/schema"
+)
+
+func resourceMachineSnapshot() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceMachineSnapshotCreate,
+		Read:   resourceMachineSnapshotRead,
+		Update: resourceMachineSnapshotUpdate,
+		Delete: resourceMachineSnapshotDelete,
+		Importer: &schema.ResourceImporter{
+			State: resourceMachineSnapshotImport,
+		},
+
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(5 * time.Minute),
+			Update: schema.DefaultTimeout(5 * time.Minute),
+			Delete: schema.DefaultTimeout(5 * time.Minute),
+		},
+
+		Schema: map[string]*schema.Schema{
+			"machine_id": &schema.Schema{
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+			"name": &schema.Schema{
+				Type:     schema.TypeString,
+				Optional: true,
+				Computed: true,
+				ForceNew: true,
+			},
+			"description": &schema.Schema{
+				Type:     schema.TypeString,
+				Optional: true,
+				ForceNew: true,
+			},
+			"snapshot_memory": &schema.Schema{
+				Type:     schema.TypeBool,
+				Optional: true,
+				ForceNew: true,
+			},
+			"revert_triggers": &schema.Schema{
+				Type:     schema.TypeMap,
+				Optional: true,
+			},
+			"created_at": &schema.Schema{
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"owner": &schema.Schema{
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"organization_id": &schema.Schema{
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+		},
+	}
+}
+
+func resourceMachineSnapshotCreate(d *schema.ResourceData, m interface{}) error {
+	log.Printf("Starting to create vra_machine_snapshot resource")
+	apiClient := m.(*Client).apiClient
+
+	machineID := d.Get("machine_id").(string)
+	snapshotSpecification := models.SnapshotSpecification{
+		Name:           d.Get("name").(string),
+		Description:    d.Get("description").(string),
+		SnapshotMemory: d.Get("snapshot_memory").(bool),
+	}
+
+	existing, err := getMachineSnapshotIDs(apiClient, machineID)
+	if err != nil {
+		return err
+	}
+
+	log.Printf("[DEBUG] create snapshot of machine %s: %#v", machineID, snapshotSpecification)
+	createSnapshotAccepted, err := apiClient.Compute.CreateMachineSnapshot(compute.NewCreateMachineSnapshotParams().WithID(machineID).WithBody(&snapshotSpecification))
+	if err != nil {
+		return err
+	}
+
+	resources, err := waitForRequestTracker(apiClient, *createSnapshotAccepted.Payload.ID, d.Timeout(schema.TimeoutCreate))
+	if err != nil {
+		return setPartialResourceID(d, err, trackedSnapshots)
+	}
+
+	// The request may only report the machine, the new snapshot is then the one
+	// the machine did not have before
+	id, err := createdResourceID(resources, trackedSnapshots, *createSnapshotAccepted.Payload.ID)
+	if err != nil {
+		snapshotIDs, listErr := getMachineSnapshotIDs(apiClient, machineID)
+		if listErr != nil {
+			return listErr
+		}
+		id, err = newSnapshotID(existing, snapshotIDs)
+		if err != nil {
+			return fmt.Errorf("error finding the snapshot request %s created on machine %s: %v", *createSnapshotAccepted.Payload.ID, machineID, err)
+		}
+	}
+
+	d.SetId(id)
+	log.Printf("Finished to create vra_machine_snapshot resource with name %s", d.Get("name"))
+
+	return resourceMachineSnapshotRead(d, m)
+}
+
+func resourceMachineSnapshotRead(d *schema.ResourceData, m interface{}) error {
+	log.Printf("Reading the vra_machine_snapshot resource with name %s", d.Get("name"))
+	apiClient := m.(*Client).apiClient
+
+	snapshot, err := getMachineSnapshot(apiClient, d.Get("machine_id").(string), d.Id())
+	if err != nil {
+		return err
+	}
+	if snapshot == nil {
+		return removeDeletedResource(d, "vra_machine_snapshot")
+	}
+
+	d.Set("name", snapshot.Name)
+	d.Set("description", snapshot.Description)
+	d.Set("created_at", snapshot.CreatedAt)
+	d.Set("owner", snapshot.Owner)
+	d.Set("organization_id", snapshot.OrganizationID)
+
+	log.Printf("Finished reading the vra_machine_snapshot resource with name %s", d.Get("name"))
+	return nil
+}
+
+func resourceMachineSnapshotUpdate(d *schema.ResourceData, m interface{}) error {
+	apiClient := m.(*Client).apiClient
+
+	// Only the revert triggers can change in place, any change reverts the machine
+	if d.HasChange("revert_triggers") && len(d.Get("revert_triggers").(map[string]interface{})) > 0 {
+		machineID := d.Get("machine_id").(string)
+		log.Printf("Starting to revert machine %s to snapshot %s", machineID, d.Id())
+
+		revertSnapshotAccepted, err := apiClient.Compute.RevertMachineSnapshot(compute.NewRevertMachineSnapshotParams().WithID(machineID).WithQueryID(d.Id()))
+		if err != nil {
+			return err
+		}
+
+		if _, err := waitForRequestTracker(apiClient, *revertSnapshotAccepted.Payload.ID, d.Timeout(schema.TimeoutUpdate)); err != nil {
+			return fmt.Errorf("error reverting machine %s to snapshot %s: %v", machineID, d.Id(), err)
+		}
+		log.Printf("Finished to revert machine %s to snapshot %s", machineID, d.Id())
+	}
+
+	return resourceMachineSnapshotRead(d, m)
+}
+
+func resourceMachineSnapshotDelete(d *schema.ResourceData, m interface{}) error {
+	log.Printf("Starting to delete the vra_machine_snapshot resource with name %s", d.Get("name"))
+	apiClient := m.(*Client).apiClient
+
+	deleteSnapshotAccepted, err := apiClient.Compute.DeleteMachineSnapshot(compute.NewDeleteMachineSnapshotParams().WithID(d.Get("machine_id").(string)).WithId1(d.Id()))
+	if err != nil {
+		return err
+	}
+
+	_, err = waitForRequestTracker(apiClient, *deleteSnapshotAccepted.Payload.ID, d.Timeout(schema.TimeoutDelete))
+	if err != nil {
+		return err
+	}
+
+	d.SetId("")
+	log.Printf("Finished deleting the vra_machine_snapshot resource with name %s", d.Get("name"))
+	return nil
+}
+
+// resourceMachineSnapshotImport imports a snapshot from a "<machine_id>/<snapshot_id>" ID
+func resourceMachineSnapshotImport(d *schema.ResourceData, m interface{}) ([]*schema.ResourceData, error) {
+	parts := strings.Split(d.Id(), "/")
+	if len(parts) != 2 || parts[0] == "" || parts[1] == "" {
+		return nil, fmt.Errorf("expected an ID of the form <machine_id>/<snapshot_id>, got %q", d.Id())
+	}
+
+	d.Set("machine_id", parts[0])
+	d.SetId(parts[1])
+	return []*schema.ResourceData{d}, nil
+}
+
+// getMachineSnapshot returns the given snapshot of a machine, nil when either does not exist
+func getMachineSnapshot(apiClient *client.MulticloudIaaS, machineID, snapshotID string) (*models.Snapshot, error) {
+	ret, err := apiClient.Compute.GetMachineSnapshots(compute.NewGetMachineSnapshotsParams().WithID(machineID))
+	if err != nil {
+		switch err.(type) {
+		case *compute.GetMachineSnapshotsNotFound:
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	for _, snapshot := range ret.Payload {
+		if snapshot.ID != nil && *snapshot.ID == snapshotID {
+			return snapshot, nil
+		}
+	}
+	return nil, nil
+}
+
+// getMachineSnapshotIDs returns the IDs of the snapshots of a machine
+func getMachineSnapshotIDs(apiClient *client.MulticloudIaaS, machineID string) (map[string]bool, error) {
+	ret, err := apiClient.Compute.GetMachineSnapshots(compute.NewGetMachineSnapshotsParams().WithID(machineID))
+	if err != nil {
+		return nil, err
+	}
+
+	ids := make(map[string]bool)
+	for _, snapshot := range ret.Payload {
+		if snapshot.ID != nil {
+			ids[*snapshot.ID] = true
+		}
+	}
+	return ids, nil
+}
+
+// newSnapshotID returns the ID of the one snapshot in after that is not in before,
+// and an error when there is none or when other snapshots were taken concurrently
+func newSnapshotID(before, after map[string]bool) (string, error) {
+	ids := make([]string, 0, 1)
+	for id := range after {
+		if !before[id] {
+			ids = append(ids, id)
+		}
+	}
+
+	if len(ids) != 1 {
+		return "", fmt.Errorf("expected exactly one new snapshot, found %d", len(ids))
+	}
+	return ids[0], nil
+}
@@ -0,0 +1,109 @@
+package vra
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform/helper/acctest"
+	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/hashicorp/terraform/terraform"
+)
+
+func TestAccVRAMachineSnapshot_Basic(t *testing.T) {
+	rInt := acctest.RandInt()
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheckMachine(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckVRAMachineSnapshotDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccCheckVRAMachineSnapshotConfig(rInt, ""),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					testAccCheckVRAMachineSnapshotExists("vra_machine_snapshot.before_patch"),
+					resource.TestCheckResourceAttr(
+						"vra_machine_snapshot.before_patch", "description", "before patching"),
+				),
+			},
+			{
+				Config: testAccCheckVRAMachineSnapshotConfig(rInt, "rollback-1"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					testAccCheckVRAMachineSnapshotExists("vra_machine_snapshot.before_patch"),
+					resource.TestCheckResourceAttr(
+						"vra_machine_snapshot.before_patch", "revert_triggers.rollback", "rollback-1"),
+				),
+			},
+		},
+	})
+}
+
+func testAccCheckVRAMachineSnapshotExists(n string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[n]
+		if !ok {
+			return fmt.Errorf("not found: %s", n)
+		}
+
+		if rs.Primary.ID == "" {
+			return fmt.Errorf("no snapshot ID is set")
+		}
+
+		return nil
+	}
+}
+
+func testAccCheckVRAMachineSnapshotDestroy(s *terraform.State) error {
+	apiClient := testAccProviderVRA.Meta().(*Client).apiClient
+
+	for _, rs := range s.RootModule().Resources {
+		if rs.Type == "vra_machine_snapshot" {
+			snapshot, err := getMachineSnapshot(apiClient, rs.Primary.Attributes["machine_id"], rs.Primary.ID)
+			if err != nil {
+				return err
+			}
+			if snapshot != nil {
+				return fmt.Errorf("Resource 'vra_machine_snapshot' still exists with id %s", rs.Primary.ID)
+			}
+		}
+	}
+
+	return testAccCheckVRAMachineDestroy(s)
+}
+
+func testAccCheckVRAMachineSnapshotConfig(rInt int, rollback string) string {
+	revertTriggers := ""
+	if rollback != "" {
+		revertTriggers = fmt.Sprintf(`
+	revert_triggers = {
+	  rollback = "%s"
+	}`, rollback)
+	}
+
+	return testAccCheckVRAMachineConfig(rInt) + fmt.Sprintf(`
+
+resource "vra_machine_snapshot" "before_patch" {
+	machine_id  = vra_machine.my_machine.id
+	description = "before patching"
+%s
+}`, revertTriggers)
+}
+
+func TestNewSnapshotID(t *testing.T) {
+	before := map[string]bool{"s-1": true}
+
+	id, err := newSnapshotID(before, map[string]bool{"s-1": true, "s-2": true})
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+	if id != "s-2" {
+		t.Fatalf("expected s-2, got %s", id)
+	}
+
+	if _, err := newSnapshotID(before, map[string]bool{"s-1": true}); err == nil {
+		t.Fatal("expected an error when no snapshot was created")
+	}
+
+	if _, err := newSnapshotID(before, map[string]bool{"s-1": true, "s-2": true, "s-3": true}); err == nil {
+		t.Fatal("expected an error when several snapshots were created")
+	}
+}
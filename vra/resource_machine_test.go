@@ -1,6 +1,7 @@
 package vra
 
 import (
+	"errors"
 	"fmt"
 	"os"
 	"regexp"
@@ -9,8 +10,10 @@ import (
 
 	"github.com/vmware/vra-sdk-go/pkg/client/compute"
 
+	"github.com/hashicorp/terraform/config"
 	"github.com/hashicorp/terraform/helper/acctest"
 	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/hashicorp/terraform/helper/schema"
 	"github.com/hashicorp/terraform/terraform"
 )
 
@@ -42,6 +45,12 @@ func TestAccVRAMachine_Basic(t *testing.T) {
 						"vra_machine.my-machine", "tags.#", "1"),
 				),
 			},
+			{
+				// The default nic and the server-side custom properties must not
+				// show up as changes
+				Config:   testAccCheckVRAMachineConfig(rInt),
+				PlanOnly: true,
+			},
 		},
 	})
 }
@@ -170,3 +179,98 @@ resource "vra_flavor_profile" "my-flavor-profile" {
 	}
 }`, name, rInt, rInt, rInt, image, rInt)
 }
+
+func TestReplaceMachine(t *testing.T) {
+	cases := []struct {
+		name      string
+		createdID string
+		createErr error
+		deleteErr error
+		id        string
+		deleted   []string
+		err       bool
+	}{
+		{"replaced", "m-2", nil, nil, "m-2", []string{"m-1"}, false},
+		{"create fails", "", errors.New("no placement"), nil, "m-1", nil, true},
+		{"create leaves a machine behind", "m-2", errors.New("timeout"), nil, "m-1", nil, true},
+		{"delete fails", "m-2", nil, errors.New("in use"), "m-2", []string{"m-1"}, true},
+	}
+	for _, c := range cases {
+		d := schema.TestResourceDataRaw(t, resourceMachine().Schema, map[string]interface{}{})
+		d.SetId("m-1")
+
+		var deleted []string
+		err := replaceMachine(d, func() error {
+			if len(deleted) > 0 {
+				t.Errorf("%s: the replacement was created after deleting the machine", c.name)
+			}
+			if c.createdID != "" {
+				d.SetId(c.createdID)
+			}
+			return c.createErr
+		}, func(id string) error {
+			deleted = append(deleted, id)
+			return c.deleteErr
+		})
+
+		if c.err && err == nil {
+			t.Errorf("%s: expected an error", c.name)
+		}
+		if !c.err && err != nil {
+			t.Errorf("%s: unexpected error %v", c.name, err)
+		}
+		if d.Id() != c.id {
+			t.Errorf("%s: expected machine %s in state, got %s", c.name, c.id, d.Id())
+		}
+		if fmt.Sprint(deleted) != fmt.Sprint(c.deleted) {
+			t.Errorf("%s: expected %v to be deleted, got %v", c.name, c.deleted, deleted)
+		}
+	}
+}
+
+func TestResourceMachineImportedDiff(t *testing.T) {
+	// vRA returned no constraints or boot_config for the imported machine
+	state := &terraform.InstanceState{
+		ID: "m-1",
+		Attributes: map[string]string{
+			"id":                       "m-1",
+			"name":                     "my-machine",
+			"project_id":               "p-1",
+			"flavor":                   "small",
+			"image":                    "ubuntu",
+			"nics.#":                   "0",
+			"custom_properties.%":      "2",
+			"custom_properties.app":    "db",
+			"custom_properties.vcUuid": "5030c4b0",
+		},
+	}
+
+	raw, err := config.NewRawConfig(map[string]interface{}{
+		"name":              "my-machine",
+		"project_id":        "p-1",
+		"flavor":            "small",
+		"image":             "ubuntu",
+		"custom_properties": map[string]interface{}{"app": "db"},
+		"constraints":       []interface{}{map[string]interface{}{"mandatory": true, "expression": "env:prod"}},
+		"boot_config":       []interface{}{map[string]interface{}{"content": "#cloud-config\npackages:\n  - nginx\n"}},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	diff, err := resourceMachine().Diff(state, terraform.NewResourceConfig(raw), nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if diff != nil && diff.RequiresNew() {
+		t.Fatalf("expected the imported machine to be kept, got %v", diff)
+	}
+
+	diff, err = resourceMachine().Diff(nil, terraform.NewResourceConfig(raw), nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if diff == nil || diff.Attributes["constraints.#"] == nil || diff.Attributes["boot_config.#"] == nil {
+		t.Fatalf("expected a new machine to be created with its constraints and boot_config, got %v", diff)
+	}
+}
@@ -3,40 +3,44 @@ package vra
 import (
 	"fmt"
 	"log"
-	"strings"
+	"net/http"
 	"time"
 
+	"github.com/vmware/vra-sdk-go/pkg/client"
 	"github.com/vmware/vra-sdk-go/pkg/client/network"
 
-	"github.com/hashicorp/terraform/helper/resource"
 	"github.com/hashicorp/terraform/helper/schema"
-	"github.com/vmware/vra-sdk-go/pkg/client"
-	"github.com/vmware/vra-sdk-go/pkg/client/request"
 	"github.com/vmware/vra-sdk-go/pkg/models"
 )
 
 func resourceNetwork() *schema.Resource {
 	return &schema.Resource{
-		Create: resourceNetworkCreate,
-		Read:   resourceNetworkRead,
-		Update: resourceNetworkUpdate,
-		Delete: resourceNetworkDelete,
+		Create:   resourceNetworkCreate,
+		Read:     resourceNetworkRead,
+		Update:   resourceNetworkUpdate,
+		Delete:   resourceNetworkDelete,
+		Importer: importByIDOrName(resourceNetworkImportList),
+
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(5 * time.Minute),
+			Update: schema.DefaultTimeout(5 * time.Minute),
+			Delete: schema.DefaultTimeout(5 * time.Minute),
+		},
+
 		Schema: map[string]*schema.Schema{
 			"name": &schema.Schema{
-				Type:     schema.TypeString,
-				Required: true,
-				DiffSuppressFunc: func(k, old, new string, d *schema.ResourceData) bool {
-					return !strings.HasPrefix(new, old)
-				},
+				Type:             schema.TypeString,
+				Required:         true,
+				DiffSuppressFunc: suppressNameSuffix,
 			},
 			"project_id": &schema.Schema{
 				Type:     schema.TypeString,
 				Required: true,
+				ForceNew: true,
 			},
-			"constraints": constraintsSchema(),
+			"constraints": forceNew(constraintsSchema()),
 			"custom_properties": &schema.Schema{
 				Type:     schema.TypeMap,
-				Computed: true,
 				Optional: true,
 			},
 			"description": &schema.Schema{
@@ -46,6 +50,7 @@ func resourceNetwork() *schema.Resource {
 			"outbound_access": &schema.Schema{
 				Type:     schema.TypeBool,
 				Optional: true,
+				ForceNew: true,
 			},
 			"tags": tagsSchema(),
 			"cidr": &schema.Schema{
@@ -111,53 +116,22 @@ func resourceNetworkCreate(d *schema.ResourceData, m interface{}) error {
 	if err != nil {
 		return err
 	}
-	stateChangeFunc := resource.StateChangeConf{
-		Delay:      5 * time.Second,
-		Pending:    []string{models.RequestTrackerStatusINPROGRESS},
-		Refresh:    networkStateRefreshFunc(*apiClient, *createNetworkCreated.Payload.ID),
-		Target:     []string{models.RequestTrackerStatusFINISHED},
-		Timeout:    5 * time.Minute,
-		MinTimeout: 5 * time.Second,
+	resources, err := waitForRequestTracker(apiClient, *createNetworkCreated.Payload.ID, d.Timeout(schema.TimeoutCreate))
+	if err != nil {
+		return setPartialResourceID(d, err, trackedNetworks)
 	}
-	resourceIds, err := stateChangeFunc.WaitForState()
-	log.Printf("Waitforstate returned: %T %+v %+v\n", resourceIds, resourceIds, err)
 
+	id, err := createdResourceID(resources, trackedNetworks, *createNetworkCreated.Payload.ID)
 	if err != nil {
 		return err
 	}
 
-	networkIDs := resourceIds.([]string)
-	d.SetId(networkIDs[0])
+	d.SetId(id)
 	log.Printf("Finished to create vra_network resource with name %s", d.Get("name"))
 
 	return resourceNetworkRead(d, m)
 }
 
-func networkStateRefreshFunc(apiClient client.MulticloudIaaS, id string) resource.StateRefreshFunc {
-	return func() (interface{}, string, error) {
-		ret, err := apiClient.Request.GetRequestTracker(request.NewGetRequestTrackerParams().WithID(id))
-		if err != nil {
-			return "", models.RequestTrackerStatusFAILED, err
-		}
-
-		status := ret.Payload.Status
-		switch *status {
-		case models.RequestTrackerStatusFAILED:
-			return []string{""}, *status, fmt.Errorf(ret.Payload.Message)
-		case models.RequestTrackerStatusINPROGRESS:
-			return [...]string{id}, *status, nil
-		case models.RequestTrackerStatusFINISHED:
-			networkIDs := make([]string, len(ret.Payload.Resources))
-			for i, r := range ret.Payload.Resources {
-				networkIDs[i] = strings.TrimPrefix(r, "/iaas/api/networks/")
-			}
-			return networkIDs, *status, nil
-		default:
-			return [...]string{id}, ret.Payload.Message, fmt.Errorf("networkStateRefreshFunc: unknown status %v", *status)
-		}
-	}
-}
-
 func resourceNetworkRead(d *schema.ResourceData, m interface{}) error {
 	log.Printf("Reading the vra_network resource with name %s", d.Get("name"))
 	apiClient := m.(*Client).apiClient
@@ -167,15 +141,14 @@ func resourceNetworkRead(d *schema.ResourceData, m interface{}) error {
 	if err != nil {
 		switch err.(type) {
 		case *network.GetNetworkNotFound:
-			d.SetId("")
-			return nil
+			return removeDeletedResource(d, "vra_network")
 		}
 		return err
 	}
 
 	network := *resp.Payload
 	d.Set("cidr", network.Cidr)
-	d.Set("custom_properties", network.CustomProperties)
+	d.Set("custom_properties", flattenManagedCustomProperties(network.CustomProperties, d.Get("custom_properties").(map[string]interface{})))
 	d.Set("description", network.Description)
 	d.Set("external_id", network.ExternalID)
 	d.Set("external_zone_id", network.ExternalZoneID)
@@ -189,6 +162,10 @@ func resourceNetworkRead(d *schema.ResourceData, m interface{}) error {
 		return fmt.Errorf("error setting network tags - error: %v", err)
 	}
 
+	if err := setConstraints(d, apiClient, "/iaas/api/networks/{id}", id); err != nil {
+		return err
+	}
+
 	if err := d.Set("links", flattenLinks(network.Links)); err != nil {
 		return fmt.Errorf("error setting network links - error: %#v", err)
 	}
@@ -198,7 +175,33 @@ func resourceNetworkRead(d *schema.ResourceData, m interface{}) error {
 }
 
 func resourceNetworkUpdate(d *schema.ResourceData, m interface{}) error {
-	return fmt.Errorf("Updating a network resource is not allowed")
+	log.Printf("Starting to update the vra_network resource with name %s", d.Get("name"))
+	apiClient := m.(*Client).apiClient
+
+	// Only name, description, tags and custom properties change in place, the other arguments are ForceNew
+	if d.HasChange("name") || d.HasChange("description") || d.HasChange("tags") || d.HasChange("custom_properties") {
+		// vRA replaces the custom properties, the ones it added are sent back with the managed ones
+		resp, err := apiClient.Network.GetNetwork(network.NewGetNetworkParams().WithID(d.Id()))
+		if err != nil {
+			return fmt.Errorf("error reading network %s: %v", d.Id(), err)
+		}
+		oldCustomProperties, newCustomProperties := d.GetChange("custom_properties")
+
+		networkUpdateSpecification := networkUpdateSpecification{
+			Name:        d.Get("name").(string),
+			Description: d.Get("description").(string),
+			Tags:        expandTags(d.Get("tags").(*schema.Set).List()),
+			CustomProperties: mergeCustomProperties(resp.Payload.CustomProperties,
+				oldCustomProperties.(map[string]interface{}), newCustomProperties.(map[string]interface{})),
+		}
+
+		if err := updateNetwork(apiClient, d.Id(), networkUpdateSpecification); err != nil {
+			return fmt.Errorf("error updating network %s: %v", d.Id(), err)
+		}
+	}
+
+	log.Printf("Finished updating the vra_network resource with name %s", d.Get("name"))
+	return resourceNetworkRead(d, m)
 }
 
 func resourceNetworkDelete(d *schema.ResourceData, m interface{}) error {
@@ -210,16 +213,7 @@ func resourceNetworkDelete(d *schema.ResourceData, m interface{}) error {
 	if err != nil {
 		return err
 	}
-	stateChangeFunc := resource.StateChangeConf{
-		Delay:      5 * time.Second,
-		Pending:    []string{models.RequestTrackerStatusINPROGRESS},
-		Refresh:    networkStateRefreshFunc(*apiClient, *deleteNetworkAccepted.Payload.ID),
-		Target:     []string{models.RequestTrackerStatusFINISHED},
-		Timeout:    5 * time.Minute,
-		MinTimeout: 5 * time.Second,
-	}
-
-	_, err = stateChangeFunc.WaitForState()
+	_, err = waitForRequestTracker(apiClient, *deleteNetworkAccepted.Payload.ID, d.Timeout(schema.TimeoutDelete))
 	if err != nil {
 		return err
 	}
@@ -228,3 +222,38 @@ func resourceNetworkDelete(d *schema.ResourceData, m interface{}) error {
 	log.Printf("Finished deleting the vra_network resource with name %s", d.Get("name"))
 	return nil
 }
+
+func resourceNetworkImportList(apiClient *client.MulticloudIaaS) ([]namedResource, error) {
+	resources := make([]namedResource, 0)
+	err := listPages(func(skip int) (int, int64, error) {
+		ret := new(models.NetworkResult)
+		if err := listPage(apiClient, "getNetworks", "/iaas/api/networks", skip, ret); err != nil {
+			return 0, 0, err
+		}
+		for _, network := range ret.Content {
+			resources = append(resources, namedResource{ID: *network.ID, Name: network.Name})
+		}
+		return len(ret.Content), ret.TotalElements, nil
+	})
+	return resources, err
+}
+
+// networkUpdateSpecification is the body of the network update, which the SDK does not provide
+type networkUpdateSpecification struct {
+	Name             string            `json:"name"`
+	Description      string            `json:"description"`
+	Tags             []*models.Tag     `json:"tags"`
+	CustomProperties map[string]string `json:"customProperties"`
+}
+
+// updateNetwork updates the name, description, tags and custom properties of a network
+func updateNetwork(apiClient *client.MulticloudIaaS, id string, spec networkUpdateSpecification) error {
+	log.Printf("[DEBUG] update network %s: %#v", id, spec)
+	return submitAPIOperation(apiClient, apiOperation{
+		ID:          "updateNetwork",
+		Method:      http.MethodPatch,
+		PathPattern: "/iaas/api/networks/{id}",
+		PathParams:  map[string]string{"id": id},
+		Body:        &spec,
+	}, new(models.Network))
+}
@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"log"
 
+	"github.com/vmware/vra-sdk-go/pkg/client"
 	"github.com/vmware/vra-sdk-go/pkg/client/network_profile"
 	"github.com/vmware/vra-sdk-go/pkg/models"
 
@@ -12,10 +13,11 @@ import (
 
 func resourceNetworkProfile() *schema.Resource {
 	return &schema.Resource{
-		Create: resourceNetworkProfileCreate,
-		Read:   resourceNetworkProfileRead,
-		Update: resourceNetworkProfileUpdate,
-		Delete: resourceNetworkProfileDelete,
+		Create:   resourceNetworkProfileCreate,
+		Read:     resourceNetworkProfileRead,
+		Update:   resourceNetworkProfileUpdate,
+		Delete:   resourceNetworkProfileDelete,
+		Importer: importByIDOrName(resourceNetworkProfileImportList),
 
 		Schema: map[string]*schema.Schema{
 			"name": &schema.Schema{
@@ -147,6 +149,10 @@ func resourceNetworkProfileRead(d *schema.ResourceData, m interface{}) error {
 	id := d.Id()
 	resp, err := apiClient.NetworkProfile.GetNetworkProfile(network_profile.NewGetNetworkProfileParams().WithID(id))
 	if err != nil {
+		switch err.(type) {
+		case *network_profile.GetNetworkProfileNotFound:
+			return removeDeletedResource(d, "vra_network_profile")
+		}
 		return err
 	}
 
@@ -162,6 +168,19 @@ func resourceNetworkProfileRead(d *schema.ResourceData, m interface{}) error {
 	d.Set("owner", networkProfile.Owner)
 	d.Set("updated_at", networkProfile.UpdatedAt)
 
+	// The profile only links the objects it was configured with
+	if regionID := linkID(networkProfile.Links, "region"); regionID != "" {
+		d.Set("region_id", regionID)
+	}
+	d.Set("isolation_network_domain_id", linkID(networkProfile.Links, "isolated-network-domain"))
+	d.Set("isolation_external_fabric_network_id", linkID(networkProfile.Links, "isolated-external-fabric-networks"))
+	if err := d.Set("fabric_network_ids", linkIDs(networkProfile.Links, "fabric-networks")); err != nil {
+		return fmt.Errorf("error setting network profile fabric networks - error: %v", err)
+	}
+	if err := d.Set("security_group_ids", linkIDs(networkProfile.Links, "security-groups")); err != nil {
+		return fmt.Errorf("error setting network profile security groups - error: %v", err)
+	}
+
 	if err := d.Set("tags", flattenTags(networkProfile.Tags)); err != nil {
 		return fmt.Errorf("error setting network profile tags - error: %v", err)
 	}
@@ -233,3 +252,18 @@ func resourceNetworkProfileDelete(d *schema.ResourceData, m interface{}) error {
 	log.Printf("Finished deleting the vra_network_profile resource with name %s", d.Get("name"))
 	return nil
 }
+
+func resourceNetworkProfileImportList(apiClient *client.MulticloudIaaS) ([]namedResource, error) {
+	resources := make([]namedResource, 0)
+	err := listPages(func(skip int) (int, int64, error) {
+		ret := new(models.NetworkProfileResult)
+		if err := listPage(apiClient, "getNetworkProfiles", "/iaas/api/network-profiles", skip, ret); err != nil {
+			return 0, 0, err
+		}
+		for _, networkProfile := range ret.Content {
+			resources = append(resources, namedResource{ID: *networkProfile.ID, Name: networkProfile.Name})
+		}
+		return len(ret.Content), ret.TotalElements, nil
+	})
+	return resources, err
+}
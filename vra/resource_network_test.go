@@ -1,11 +1,15 @@
 package vra
 
 import (
+	"encoding/json"
 	"fmt"
+	"net/http"
+	"net/http/httptest"
 	"regexp"
 	"strconv"
 	"testing"
 
+	"github.com/hashicorp/terraform/config"
 	"github.com/hashicorp/terraform/helper/acctest"
 	"github.com/hashicorp/terraform/helper/resource"
 	"github.com/hashicorp/terraform/terraform"
@@ -13,6 +17,7 @@ import (
 
 func TestAccVRANetwork_Basic(t *testing.T) {
 	rInt := acctest.RandInt()
+	var networkID string
 
 	resource.Test(t, resource.TestCase{
 		PreCheck:     func() { testAccPreCheck(t) },
@@ -23,6 +28,7 @@ func TestAccVRANetwork_Basic(t *testing.T) {
 				Config: testAccCheckVRANetworkConfig(rInt),
 				Check: resource.ComposeAggregateTestCheckFunc(
 					testAccCheckVRANetworkExists("vra_network.my_network"),
+					testAccCheckVRANetworkID("vra_network.my_network", &networkID),
 					resource.TestMatchResourceAttr(
 						"vra_network.my_network", "name", regexp.MustCompile("^terraform_vra_network-"+strconv.Itoa(rInt))),
 					resource.TestCheckResourceAttr(
@@ -43,6 +49,24 @@ func TestAccVRANetwork_Basic(t *testing.T) {
 						"vra_network.my_network", "tags.0.value", "genchev"),
 				),
 			},
+			{
+				Config: testAccCheckVRANetworkUpdateConfig(rInt),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					testAccCheckVRANetworkExists("vra_network.my_network"),
+					testAccCheckVRANetworkNotRecreated("vra_network.my_network", &networkID),
+					resource.TestCheckResourceAttr(
+						"vra_network.my_network", "description", "updated in place"),
+					resource.TestCheckResourceAttr(
+						"vra_network.my_network", "tags.#", "1"),
+					resource.TestCheckResourceAttr(
+						"vra_network.my_network", "tags.0.key", "cost-center"),
+				),
+			},
+			{
+				ResourceName:      "vra_network.my_network",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
 		},
 	})
 }
@@ -62,6 +86,34 @@ func testAccCheckVRANetworkExists(n string) resource.TestCheckFunc {
 	}
 }
 
+// testAccCheckVRANetworkID records the ID of the network
+func testAccCheckVRANetworkID(n string, id *string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[n]
+		if !ok {
+			return fmt.Errorf("Not found: %s", n)
+		}
+
+		*id = rs.Primary.ID
+		return nil
+	}
+}
+
+// testAccCheckVRANetworkNotRecreated checks that the network was updated in place
+func testAccCheckVRANetworkNotRecreated(n string, id *string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[n]
+		if !ok {
+			return fmt.Errorf("Not found: %s", n)
+		}
+
+		if rs.Primary.ID != *id {
+			return fmt.Errorf("expected network %s to be updated in place, it was replaced by %s", *id, rs.Primary.ID)
+		}
+		return nil
+	}
+}
+
 func testAccCheckVRANetworkDestroy(s *terraform.State) error {
 	/*
 		apiClient := testAccProviderVRA.Meta().(*Client).apiClient
@@ -101,3 +153,157 @@ resource "vra_network" "my_network" {
   }
 }`, rInt)
 }
+
+func testAccCheckVRANetworkUpdateConfig(rInt int) string {
+	return fmt.Sprintf(`
+resource "vra_network" "my_network" {
+  name = "terraform_vra_network-%d"
+  description = "updated in place"
+  outbound_access = false
+
+  tags {
+	key = "cost-center"
+    value = "42"
+  }
+
+  constraints {
+	  mandatory = true
+	  expression = "pci"
+  }
+}`, rInt)
+}
+
+func TestUpdateNetwork(t *testing.T) {
+	var body map[string]interface{}
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPatch || r.URL.Path != "/iaas/api/networks/n-1" {
+			t.Errorf("unexpected request %s %s", r.Method, r.URL)
+		}
+		json.NewDecoder(r.Body).Decode(&body)
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"id":"n-1","name":"my-network"}`))
+	}))
+	defer server.Close()
+
+	err := updateNetwork(testServerAPIClient(server), "n-1", networkUpdateSpecification{
+		Name:             "my-network",
+		Description:      "",
+		Tags:             expandTags([]interface{}{map[string]interface{}{"key": "cost-center", "value": "42"}}),
+		CustomProperties: expandCustomProperties(map[string]interface{}{}),
+	})
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+
+	tags, ok := body["tags"].([]interface{})
+	if !ok || len(tags) != 1 || tags[0].(map[string]interface{})["key"] != "cost-center" {
+		t.Fatalf("expected the tags to be sent, got %v", body)
+	}
+	if _, ok := body["description"]; !ok {
+		t.Fatalf("expected an empty description to be sent to clear it, got %v", body)
+	}
+	if customProperties, ok := body["customProperties"].(map[string]interface{}); !ok || len(customProperties) != 0 {
+		t.Fatalf("expected empty custom properties to be sent to clear them, got %v", body)
+	}
+}
+
+func TestResourceNetworkDiff(t *testing.T) {
+	state := &terraform.InstanceState{
+		ID: "n-1",
+		Attributes: map[string]string{
+			"id":              "n-1",
+			"name":            "my-network",
+			"project_id":      "p-1",
+			"outbound_access": "false",
+			"description":     "",
+		},
+	}
+
+	cases := []struct {
+		name        string
+		config      map[string]interface{}
+		requiresNew bool
+	}{
+		{"description", map[string]interface{}{"description": "frontend"}, false},
+		{"tags", map[string]interface{}{"tags": []interface{}{map[string]interface{}{"key": "cost-center", "value": "42"}}}, false},
+		{"custom properties", map[string]interface{}{"custom_properties": map[string]interface{}{"app": "web"}}, false},
+		{"project", map[string]interface{}{"project_id": "p-2"}, true},
+		{"outbound access", map[string]interface{}{"outbound_access": true}, true},
+		{"constraints", map[string]interface{}{"constraints": []interface{}{map[string]interface{}{"mandatory": true, "expression": "pci"}}}, true},
+	}
+	for _, c := range cases {
+		configMap := map[string]interface{}{
+			"name":       "my-network",
+			"project_id": "p-1",
+		}
+		for k, v := range c.config {
+			configMap[k] = v
+		}
+		raw, err := config.NewRawConfig(configMap)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		diff, err := resourceNetwork().Diff(state, terraform.NewResourceConfig(raw), nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if diff == nil || diff.RequiresNew() != c.requiresNew {
+			t.Errorf("%s: expected a diff with RequiresNew %t, got %#v", c.name, c.requiresNew, diff)
+		}
+	}
+}
+
+func TestResourceNetworkNameAndCustomPropertiesDiff(t *testing.T) {
+	state := &terraform.InstanceState{
+		ID: "n-1",
+		Attributes: map[string]string{
+			"id":                    "n-1",
+			"name":                  "my-network-mcm12",
+			"project_id":            "p-1",
+			"outbound_access":       "false",
+			"custom_properties.%":   "1",
+			"custom_properties.app": "web",
+		},
+	}
+
+	cases := []struct {
+		name    string
+		config  map[string]interface{}
+		changed string
+	}{
+		{"unchanged", map[string]interface{}{"name": "my-network", "custom_properties": map[string]interface{}{"app": "web"}}, ""},
+		{"custom properties cleared", map[string]interface{}{"name": "my-network"}, "custom_properties.app"},
+		{"renamed with a suffix", map[string]interface{}{"name": "my-network-mcm12-v2", "custom_properties": map[string]interface{}{"app": "web"}}, "name"},
+		{"renamed", map[string]interface{}{"name": "other-network", "custom_properties": map[string]interface{}{"app": "web"}}, "name"},
+	}
+	for _, c := range cases {
+		configMap := map[string]interface{}{
+			"project_id": "p-1",
+		}
+		for k, v := range c.config {
+			configMap[k] = v
+		}
+		raw, err := config.NewRawConfig(configMap)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		diff, err := resourceNetwork().Diff(state, terraform.NewResourceConfig(raw), nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		for _, key := range []string{"name", "custom_properties.app"} {
+			changed := false
+			if diff != nil {
+				_, changed = diff.Attributes[key]
+			}
+			if changed != (key == c.changed) {
+				t.Errorf("%s: expected a change of %q only, got %#v", c.name, c.changed, diff)
+			}
+		}
+		if c.changed != "" && (diff == nil || diff.RequiresNew()) {
+			t.Errorf("%s: expected %s to be updated in place, got %#v", c.name, c.changed, diff)
+		}
+	}
+}
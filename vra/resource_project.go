@@ -2,16 +2,18 @@ package vra
 
 import (
 	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/vmware/vra-sdk-go/pkg/client"
 	"github.com/vmware/vra-sdk-go/pkg/client/project"
 	"github.com/vmware/vra-sdk-go/pkg/models"
 )
 
 func resourceProject() *schema.Resource {
 	return &schema.Resource{
-		Create: resourceProjectCreate,
-		Read:   resourceProjectRead,
-		Update: resourceProjectUpdate,
-		Delete: resourceProjectDelete,
+		Create:   resourceProjectCreate,
+		Read:     resourceProjectRead,
+		Update:   resourceProjectUpdate,
+		Delete:   resourceProjectDelete,
+		Importer: importByIDOrName(resourceProjectImportList),
 
 		Schema: map[string]*schema.Schema{
 			"administrators": &schema.Schema{
@@ -93,8 +95,7 @@ func resourceProjectRead(d *schema.ResourceData, m interface{}) error {
 	if err != nil {
 		switch err.(type) {
 		case *project.GetProjectNotFound:
-			d.SetId("")
-			return nil
+			return removeDeletedResource(d, "vra_project")
 		}
 		return err
 	}
@@ -209,3 +210,18 @@ func flattenZoneAssignment(list []*models.ZoneAssignmentConfig) []map[string]int
 	}
 	return result
 }
+
+func resourceProjectImportList(apiClient *client.MulticloudIaaS) ([]namedResource, error) {
+	resources := make([]namedResource, 0)
+	err := listPages(func(skip int) (int, int64, error) {
+		ret := new(models.ProjectResult)
+		if err := listPage(apiClient, "getProjects", "/iaas/api/projects", skip, ret); err != nil {
+			return 0, 0, err
+		}
+		for _, project := range ret.Content {
+			resources = append(resources, namedResource{ID: *project.ID, Name: project.Name})
+		}
+		return len(ret.Content), ret.TotalElements, nil
+	})
+	return resources, err
+}
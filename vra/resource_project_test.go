@@ -44,6 +44,12 @@ func TestAccVRAProjectBasic(t *testing.T) {
 						"vra_project.my-project", "zone_assignments.max_instances", "2"),
 				),
 			},
+			{
+				ResourceName:      "vra_project.my-project",
+				ImportState:       true,
+				ImportStateVerify: true,
+				ImportStateId:     "my-project-" + strconv.Itoa(rInt),
+			},
 		},
 	})
 }
@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"log"
 
+	"github.com/vmware/vra-sdk-go/pkg/client"
 	"github.com/vmware/vra-sdk-go/pkg/client/storage_profile"
 	"github.com/vmware/vra-sdk-go/pkg/models"
 
@@ -12,10 +13,11 @@ import (
 
 func resourceStorageProfile() *schema.Resource {
 	return &schema.Resource{
-		Create: resourceStorageProfileCreate,
-		Read:   resourceStorageProfileRead,
-		Update: resourceStorageProfileUpdate,
-		Delete: resourceStorageProfileDelete,
+		Create:   resourceStorageProfileCreate,
+		Read:     resourceStorageProfileRead,
+		Update:   resourceStorageProfileUpdate,
+		Delete:   resourceStorageProfileDelete,
+		Importer: importByIDOrName(resourceStorageProfileImportList),
 
 		Schema: map[string]*schema.Schema{
 			"name": &schema.Schema{
@@ -116,6 +118,10 @@ func resourceStorageProfileRead(d *schema.ResourceData, m interface{}) error {
 	id := d.Id()
 	resp, err := apiClient.StorageProfile.GetStorageProfile(storage_profile.NewGetStorageProfileParams().WithID(id))
 	if err != nil {
+		switch err.(type) {
+		case *storage_profile.GetStorageProfileNotFound:
+			return removeDeletedResource(d, "vra_storage_profile")
+		}
 		return err
 	}
 
@@ -125,6 +131,9 @@ func resourceStorageProfileRead(d *schema.ResourceData, m interface{}) error {
 	d.Set("description", storageProfile.Description)
 	d.Set("disk_properties", storageProfile.DiskProperties)
 	d.Set("external_region_id", storageProfile.ExternalRegionID)
+	if regionID := linkID(storageProfile.Links, "region"); regionID != "" {
+		d.Set("region_id", regionID)
+	}
 	d.Set("name", storageProfile.Name)
 	d.Set("organization_id", storageProfile.OrganizationID)
 	d.Set("owner", storageProfile.Owner)
@@ -186,3 +195,18 @@ func resourceStorageProfileDelete(d *schema.ResourceData, m interface{}) error {
 	log.Printf("Finished deleting the vra_storage_profile resource with name %s", d.Get("name"))
 	return nil
 }
+
+func resourceStorageProfileImportList(apiClient *client.MulticloudIaaS) ([]namedResource, error) {
+	resources := make([]namedResource, 0)
+	err := listPages(func(skip int) (int, int64, error) {
+		ret := new(models.StorageProfileResult)
+		if err := listPage(apiClient, "getStorageProfiles", "/iaas/api/storage-profiles", skip, ret); err != nil {
+			return 0, 0, err
+		}
+		for _, storageProfile := range ret.Content {
+			resources = append(resources, namedResource{ID: *storageProfile.ID, Name: storageProfile.Name})
+		}
+		return len(ret.Content), ret.TotalElements, nil
+	})
+	return resources, err
+}
@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"log"
 
+	"github.com/vmware/vra-sdk-go/pkg/client"
 	"github.com/vmware/vra-sdk-go/pkg/client/storage_profile"
 	"github.com/vmware/vra-sdk-go/pkg/models"
 
@@ -12,10 +13,11 @@ import (
 
 func resourceStorageProfileAws() *schema.Resource {
 	return &schema.Resource{
-		Create: resourceStorageProfileAwsCreate,
-		Read:   resourceStorageProfileAwsRead,
-		Update: resourceStorageProfileAwsUpdate,
-		Delete: resourceStorageProfileAwsDelete,
+		Create:   resourceStorageProfileAwsCreate,
+		Read:     resourceStorageProfileAwsRead,
+		Update:   resourceStorageProfileAwsUpdate,
+		Delete:   resourceStorageProfileAwsDelete,
+		Importer: importByIDOrName(resourceStorageProfileAwsImportList),
 
 		Schema: map[string]*schema.Schema{
 			"name": &schema.Schema{
@@ -123,6 +125,10 @@ func resourceStorageProfileAwsRead(d *schema.ResourceData, m interface{}) error
 	id := d.Id()
 	resp, err := apiClient.StorageProfile.GetAwsStorageProfile(storage_profile.NewGetAwsStorageProfileParams().WithID(id))
 	if err != nil {
+		switch err.(type) {
+		case *storage_profile.GetAwsStorageProfileNotFound:
+			return removeDeletedResource(d, "vra_storage_profile_aws")
+		}
 		return err
 	}
 
@@ -132,6 +138,9 @@ func resourceStorageProfileAwsRead(d *schema.ResourceData, m interface{}) error
 	d.Set("description", awsStorageProfile.Description)
 	d.Set("device_type", awsStorageProfile.DeviceType)
 	d.Set("external_region_id", awsStorageProfile.ExternalRegionID)
+	if regionID := linkID(awsStorageProfile.Links, "region"); regionID != "" {
+		d.Set("region_id", regionID)
+	}
 	d.Set("iops", awsStorageProfile.Iops)
 	d.Set("name", awsStorageProfile.Name)
 	d.Set("organization_id", awsStorageProfile.OrganizationID)
@@ -196,3 +205,18 @@ func resourceStorageProfileAwsDelete(d *schema.ResourceData, m interface{}) erro
 	log.Printf("Finished deleting the vra_aws_storage_profile resource with name %s", d.Get("name"))
 	return nil
 }
+
+func resourceStorageProfileAwsImportList(apiClient *client.MulticloudIaaS) ([]namedResource, error) {
+	resources := make([]namedResource, 0)
+	err := listPages(func(skip int) (int, int64, error) {
+		ret := new(models.StorageProfileAwsResult)
+		if err := listPage(apiClient, "getAwsStorageProfiles", "/iaas/api/storage-profiles-aws", skip, ret); err != nil {
+			return 0, 0, err
+		}
+		for _, storageProfile := range ret.Content {
+			resources = append(resources, namedResource{ID: *storageProfile.ID, Name: storageProfile.Name})
+		}
+		return len(ret.Content), ret.TotalElements, nil
+	})
+	return resources, err
+}
@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"log"
 
+	"github.com/vmware/vra-sdk-go/pkg/client"
 	"github.com/vmware/vra-sdk-go/pkg/client/storage_profile"
 	"github.com/vmware/vra-sdk-go/pkg/models"
 
@@ -12,10 +13,11 @@ import (
 
 func resourceStorageProfileAzure() *schema.Resource {
 	return &schema.Resource{
-		Create: resourceStorageProfileAzureCreate,
-		Read:   resourceStorageProfileAzureRead,
-		Update: resourceStorageProfileAzureUpdate,
-		Delete: resourceStorageProfileAzureDelete,
+		Create:   resourceStorageProfileAzureCreate,
+		Read:     resourceStorageProfileAzureRead,
+		Update:   resourceStorageProfileAzureUpdate,
+		Delete:   resourceStorageProfileAzureDelete,
+		Importer: importByIDOrName(resourceStorageProfileAzureImportList),
 
 		Schema: map[string]*schema.Schema{
 			"name": &schema.Schema{
@@ -128,6 +130,10 @@ func resourceStorageProfileAzureRead(d *schema.ResourceData, m interface{}) erro
 	id := d.Id()
 	resp, err := apiClient.StorageProfile.GetAzureStorageProfile(storage_profile.NewGetAzureStorageProfileParams().WithID(id))
 	if err != nil {
+		switch err.(type) {
+		case *storage_profile.GetAzureStorageProfileNotFound:
+			return removeDeletedResource(d, "vra_storage_profile_azure")
+		}
 		return err
 	}
 
@@ -138,6 +144,12 @@ func resourceStorageProfileAzureRead(d *schema.ResourceData, m interface{}) erro
 	d.Set("disk_type", AzureStorageProfile.DiskType)
 	d.Set("data_disk_caching", AzureStorageProfile.DataDiskCaching)
 	d.Set("external_region_id", AzureStorageProfile.ExternalRegionID)
+	if regionID := linkID(AzureStorageProfile.Links, "region"); regionID != "" {
+		d.Set("region_id", regionID)
+	}
+	if storageAccountID := linkID(AzureStorageProfile.Links, "storage-account"); storageAccountID != "" {
+		d.Set("storage_account_id", storageAccountID)
+	}
 	d.Set("name", AzureStorageProfile.Name)
 	d.Set("organization_id", AzureStorageProfile.OrganizationID)
 	d.Set("os_disk_caching", AzureStorageProfile.OsDiskCaching)
@@ -201,3 +213,18 @@ func resourceStorageProfileAzureDelete(d *schema.ResourceData, m interface{}) er
 	log.Printf("Finished deleting the vra_azure_storage_profile resource with name %s", d.Get("name"))
 	return nil
 }
+
+func resourceStorageProfileAzureImportList(apiClient *client.MulticloudIaaS) ([]namedResource, error) {
+	resources := make([]namedResource, 0)
+	err := listPages(func(skip int) (int, int64, error) {
+		ret := new(models.StorageProfileAzureResult)
+		if err := listPage(apiClient, "getAzureStorageProfiles", "/iaas/api/storage-profiles-azure", skip, ret); err != nil {
+			return 0, 0, err
+		}
+		for _, storageProfile := range ret.Content {
+			resources = append(resources, namedResource{ID: *storageProfile.ID, Name: storageProfile.Name})
+		}
+		return len(ret.Content), ret.TotalElements, nil
+	})
+	return resources, err
+}
@@ -4,16 +4,18 @@ import (
 	"fmt"
 
 	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/vmware/vra-sdk-go/pkg/client"
 	"github.com/vmware/vra-sdk-go/pkg/client/location"
 	"github.com/vmware/vra-sdk-go/pkg/models"
 )
 
 func resourceZone() *schema.Resource {
 	return &schema.Resource{
-		Create: resourceZoneCreate,
-		Read:   resourceZoneRead,
-		Update: resourceZoneUpdate,
-		Delete: resourceZoneDelete,
+		Create:   resourceZoneCreate,
+		Read:     resourceZoneRead,
+		Update:   resourceZoneUpdate,
+		Delete:   resourceZoneDelete,
+		Importer: importByIDOrName(resourceZoneImportList),
 
 		Schema: map[string]*schema.Schema{
 			"description": &schema.Schema{
@@ -88,8 +90,7 @@ func resourceZoneRead(d *schema.ResourceData, m interface{}) error {
 	if err != nil {
 		switch err.(type) {
 		case *location.GetZoneNotFound:
-			d.SetId("")
-			return nil
+			return removeDeletedResource(d, "vra_zone")
 		}
 		return err
 	}
@@ -97,6 +98,9 @@ func resourceZoneRead(d *schema.ResourceData, m interface{}) error {
 	d.Set("description", zone.Description)
 	d.Set("name", zone.Name)
 	d.Set("placement_policy", zone.PlacementPolicy)
+	if regionID := linkID(zone.Links, "region"); regionID != "" {
+		d.Set("region_id", regionID)
+	}
 	if err := d.Set("tags", flattenTags(zone.Tags)); err != nil {
 		return fmt.Errorf("Error setting zone tags - error: %#v", err)
 	}
@@ -145,3 +149,18 @@ func resourceZoneDelete(d *schema.ResourceData, m interface{}) error {
 
 	return nil
 }
+
+func resourceZoneImportList(apiClient *client.MulticloudIaaS) ([]namedResource, error) {
+	resources := make([]namedResource, 0)
+	err := listPages(func(skip int) (int, int64, error) {
+		ret := new(models.ZoneResult)
+		if err := listPage(apiClient, "getZones", "/iaas/api/zones", skip, ret); err != nil {
+			return 0, 0, err
+		}
+		for _, zone := range ret.Content {
+			resources = append(resources, namedResource{ID: *zone.ID, Name: zone.Name})
+		}
+		return len(ret.Content), ret.TotalElements, nil
+	})
+	return resources, err
+}
@@ -48,6 +48,11 @@ func TestAccVRAZoneBasic(t *testing.T) {
 						"vra_zone.my-zone", "tags.#", "2"),
 				),
 			},
+			{
+				ResourceName:      "vra_zone.my-zone",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
 		},
 	})
 }
@@ -0,0 +1,168 @@
+package vra
+
+import (
+	"context"
+	"errors"
+	"io"
+	"io/ioutil"
+	"log"
+	"math/rand"
+	"net"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+// RetryOptions holds the retry settings for throttled and transient API failures from provider config
+type RetryOptions struct {
+	MaxRetries int
+	WaitMin    time.Duration
+	WaitMax    time.Duration
+}
+
+// retryTransport replays requests that failed with a throttling or transient
+// error, waiting with exponential backoff or as long as the server asks in its
+// Retry-After header, up to the maximum wait. Only requests that are safe to replay are retried: GETs,
+// and POSTs the server did not accept.
+type retryTransport struct {
+	transport http.RoundTripper
+	options   RetryOptions
+}
+
+func newRetryTransport(transport http.RoundTripper, options RetryOptions) *retryTransport {
+	return &retryTransport{
+		transport: transport,
+		options:   options,
+	}
+}
+
+// RoundTrip implements http.RoundTripper
+func (t *retryTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	attemptReq := req
+	for attempt := 0; ; attempt++ {
+		resp, err := t.transport.RoundTrip(attemptReq)
+		if attempt >= t.options.MaxRetries || !shouldRetry(req, resp, err) {
+			return resp, err
+		}
+
+		// The body of the previous attempt has been consumed, it can only be
+		// replayed when the request knows how to rebuild it
+		if req.Body != nil {
+			if req.GetBody == nil {
+				return resp, err
+			}
+			body, bodyErr := req.GetBody()
+			if bodyErr != nil {
+				return resp, err
+			}
+			attemptReq = req.WithContext(req.Context())
+			attemptReq.Body = body
+		}
+
+		wait := t.backoff(attempt, resp)
+		if err != nil {
+			log.Printf("[DEBUG] %s %s failed: %v, retrying in %s", req.Method, req.URL.Path, err, wait)
+		} else {
+			log.Printf("[DEBUG] %s %s returned %s, retrying in %s", req.Method, req.URL.Path, resp.Status, wait)
+			_, _ = io.Copy(ioutil.Discard, resp.Body)
+			resp.Body.Close()
+		}
+
+		if err := sleepContext(req.Context(), wait); err != nil {
+			return nil, err
+		}
+	}
+}
+
+// backoff returns how long to wait before the next attempt
+func (t *retryTransport) backoff(attempt int, resp *http.Response) time.Duration {
+	if resp != nil {
+		// A server asking for a longer wait would block the provider for that long
+		if wait, ok := retryAfter(resp); ok {
+			if wait > t.options.WaitMax {
+				wait = t.options.WaitMax
+			}
+			return wait
+		}
+	}
+
+	wait := t.options.WaitMin << uint(attempt)
+	if wait <= 0 || wait > t.options.WaitMax {
+		wait = t.options.WaitMax
+	}
+
+	// Spread the retries of parallel resources so they don't hit the server in lockstep
+	if half := int64(wait / 2); half > 0 {
+		wait = time.Duration(half + rand.Int63n(half))
+	}
+
+	return wait
+}
+
+// shouldRetry reports whether a request can safely be replayed after the given outcome
+func shouldRetry(req *http.Request, resp *http.Response, err error) bool {
+	if err != nil {
+		if req.Context().Err() != nil {
+			return false
+		}
+		switch req.Method {
+		case http.MethodGet:
+			return true
+		case http.MethodPost:
+			// The request never reached the server when the connection could not be opened
+			var opErr *net.OpError
+			return errors.As(err, &opErr) && opErr.Op == "dial"
+		}
+		return false
+	}
+
+	switch req.Method {
+	case http.MethodGet:
+		switch resp.StatusCode {
+		case http.StatusTooManyRequests, http.StatusBadGateway, http.StatusServiceUnavailable, http.StatusGatewayTimeout:
+			return true
+		}
+	case http.MethodPost:
+		// Throttled or unavailable, the server has not accepted the request
+		switch resp.StatusCode {
+		case http.StatusTooManyRequests, http.StatusServiceUnavailable:
+			return true
+		}
+	}
+
+	return false
+}
+
+// retryAfter parses the Retry-After header, given either in seconds or as an HTTP date
+func retryAfter(resp *http.Response) (time.Duration, bool) {
+	value := resp.Header.Get("Retry-After")
+	if value == "" {
+		return 0, false
+	}
+
+	if seconds, err := strconv.Atoi(value); err == nil && seconds >= 0 {
+		return time.Duration(seconds) * time.Second, true
+	}
+
+	if date, err := http.ParseTime(value); err == nil {
+		if wait := time.Until(date); wait > 0 {
+			return wait, true
+		}
+		return 0, true
+	}
+
+	return 0, false
+}
+
+// sleepContext waits for the given duration or until the context is done
+func sleepContext(ctx context.Context, d time.Duration) error {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
@@ -0,0 +1,118 @@
+package vra
+
+import (
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+func testRetryServer(statuses ...int) (*httptest.Server, *[]string) {
+	var bodies []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		b, _ := ioutil.ReadAll(r.Body)
+		bodies = append(bodies, string(b))
+		status := http.StatusOK
+		if len(bodies) <= len(statuses) {
+			status = statuses[len(bodies)-1]
+		}
+		if status == http.StatusTooManyRequests {
+			w.Header().Set("Retry-After", "0")
+		}
+		w.WriteHeader(status)
+	}))
+	return server, &bodies
+}
+
+func testRetryTransport() *retryTransport {
+	return newRetryTransport(http.DefaultTransport, RetryOptions{
+		MaxRetries: 2,
+		WaitMin:    time.Millisecond,
+		WaitMax:    10 * time.Millisecond,
+	})
+}
+
+func TestRetryTransport_retriesTransientGet(t *testing.T) {
+	server, calls := testRetryServer(http.StatusServiceUnavailable, http.StatusBadGateway)
+	defer server.Close()
+
+	req, _ := http.NewRequest("GET", server.URL, nil)
+	resp, err := testRetryTransport().RoundTrip(req)
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+
+	if resp.StatusCode != http.StatusOK || len(*calls) != 3 {
+		t.Fatalf("expected status 200 after 3 calls, got %d after %d calls", resp.StatusCode, len(*calls))
+	}
+}
+
+func TestRetryTransport_retriesThrottledPost(t *testing.T) {
+	server, calls := testRetryServer(http.StatusTooManyRequests)
+	defer server.Close()
+
+	req, _ := http.NewRequest("POST", server.URL, strings.NewReader(`{"name":"machine"}`))
+	resp, err := testRetryTransport().RoundTrip(req)
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+
+	if resp.StatusCode != http.StatusOK || len(*calls) != 2 {
+		t.Fatalf("expected status 200 after 2 calls, got %d after %d calls", resp.StatusCode, len(*calls))
+	}
+	if (*calls)[1] != `{"name":"machine"}` {
+		t.Fatalf("expected the request body to be replayed, got %q", (*calls)[1])
+	}
+}
+
+func TestRetryTransport_doesNotReplayAcceptedPost(t *testing.T) {
+	server, calls := testRetryServer(http.StatusBadGateway)
+	defer server.Close()
+
+	req, _ := http.NewRequest("POST", server.URL, strings.NewReader(`{"name":"machine"}`))
+	resp, err := testRetryTransport().RoundTrip(req)
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+
+	if resp.StatusCode != http.StatusBadGateway || len(*calls) != 1 {
+		t.Fatalf("expected status 502 after 1 call, got %d after %d calls", resp.StatusCode, len(*calls))
+	}
+}
+
+func TestRetryAfter(t *testing.T) {
+	resp := &http.Response{Header: http.Header{}}
+	if _, ok := retryAfter(resp); ok {
+		t.Fatal("expected no Retry-After without the header")
+	}
+
+	resp.Header.Set("Retry-After", "7")
+	if wait, ok := retryAfter(resp); !ok || wait != 7*time.Second {
+		t.Fatalf("expected 7s, got %s", wait)
+	}
+
+	resp.Header.Set("Retry-After", time.Now().Add(time.Minute).UTC().Format(http.TimeFormat))
+	if wait, ok := retryAfter(resp); !ok || wait <= 0 || wait > time.Minute {
+		t.Fatalf("expected about a minute, got %s", wait)
+	}
+}
+
+func TestRetryTransport_capsRetryAfter(t *testing.T) {
+	transport := testRetryTransport()
+
+	for _, value := range []string{"3600", time.Now().Add(24 * time.Hour).UTC().Format(http.TimeFormat)} {
+		resp := &http.Response{Header: http.Header{}}
+		resp.Header.Set("Retry-After", value)
+		if wait := transport.backoff(0, resp); wait != transport.options.WaitMax {
+			t.Fatalf("expected Retry-After %s to be capped at %s, got %s", value, transport.options.WaitMax, wait)
+		}
+	}
+
+	resp := &http.Response{Header: http.Header{}}
+	resp.Header.Set("Retry-After", "0")
+	if wait := transport.backoff(0, resp); wait != 0 {
+		t.Fatalf("expected a shorter Retry-After to be kept, got %s", wait)
+	}
+}
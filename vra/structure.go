@@ -5,6 +5,8 @@ import (
 	"strings"
 
 	"github.com/vmware/vra-sdk-go/pkg/models"
+
+	"github.com/hashicorp/terraform/helper/schema"
 )
 
 // withString will return a string pointer of the passed in string value
@@ -16,6 +18,34 @@ func withBool(b bool) *bool {
 	return &b
 }
 
+// forceNew will mark the passed in schema as forcing a new resource when it changes
+func forceNew(s *schema.Schema) *schema.Schema {
+	s.ForceNew = true
+	return s
+}
+
+// computed will mark the passed in schema as read back from vRA when it is not configured
+func computed(s *schema.Schema) *schema.Schema {
+	s.Computed = true
+	return s
+}
+
+// notReadBack will mark the passed in set schema as not returned by vRA for an existing
+// resource, so that an imported resource with no value in state shows no change for it
+func notReadBack(s *schema.Schema) *schema.Schema {
+	s.DiffSuppressFunc = func(k, old, new string, d *schema.ResourceData) bool {
+		o, _ := d.GetChange(strings.SplitN(k, ".", 2)[0])
+		return d.Id() != "" && o.(*schema.Set).Len() == 0
+	}
+	return s
+}
+
+// suppressNameSuffix is a DiffSuppressFunc ignoring the suffix vRA appends to the
+// configured name of the objects it creates
+func suppressNameSuffix(k, old, new string, d *schema.ResourceData) bool {
+	return strings.HasPrefix(old, new)
+}
+
 // expandStringList will convert the interface list into a list of strings
 func expandStringList(slist []interface{}) []string {
 	vs := make([]string, 0, len(slist))
@@ -0,0 +1,149 @@
+package vra
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"io"
+	"io/ioutil"
+	"log"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+)
+
+// tokenRenewalWindow is how long before its expiry an access token is renewed
+const tokenRenewalWindow = 2 * time.Minute
+
+// tokenRefreshFunc exchanges the provider credentials for a new access token
+type tokenRefreshFunc func() (string, error)
+
+// bearerTokenTransport sets the bearer token on every request. When a refresh
+// function is configured the token is renewed shortly before it expires, and a
+// request rejected with a 401 is retried once with a freshly exchanged token.
+type bearerTokenTransport struct {
+	transport http.RoundTripper
+	refresh   tokenRefreshFunc
+
+	mu     sync.Mutex
+	token  string
+	expiry time.Time
+}
+
+func newBearerTokenTransport(transport http.RoundTripper, token string, refresh tokenRefreshFunc) *bearerTokenTransport {
+	return &bearerTokenTransport{
+		transport: transport,
+		refresh:   refresh,
+		token:     token,
+		expiry:    tokenExpiry(token),
+	}
+}
+
+// RoundTrip implements http.RoundTripper
+func (t *bearerTokenTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	token, err := t.currentToken()
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := t.transport.RoundTrip(withBearerToken(req, token))
+	if err != nil || resp.StatusCode != http.StatusUnauthorized || t.refresh == nil {
+		return resp, err
+	}
+
+	// The body of the original request has been consumed, it can only be
+	// replayed when the request knows how to rebuild it
+	if req.Body != nil && req.GetBody == nil {
+		return resp, nil
+	}
+
+	log.Printf("[DEBUG] %s %s returned 401, renewing the access token", req.Method, req.URL.Path)
+	token, err = t.renew(token)
+	if err != nil {
+		return resp, nil
+	}
+
+	retry := withBearerToken(req, token)
+	if req.GetBody != nil {
+		body, err := req.GetBody()
+		if err != nil {
+			return resp, nil
+		}
+		retry.Body = body
+	}
+
+	_, _ = io.Copy(ioutil.Discard, resp.Body)
+	resp.Body.Close()
+
+	return t.transport.RoundTrip(retry)
+}
+
+// currentToken returns the access token, renewing it first when it is about to expire
+func (t *bearerTokenTransport) currentToken() (string, error) {
+	t.mu.Lock()
+	token, expiry := t.token, t.expiry
+	t.mu.Unlock()
+
+	if t.refresh == nil || expiry.IsZero() || time.Now().Add(tokenRenewalWindow).Before(expiry) {
+		return token, nil
+	}
+
+	log.Printf("[DEBUG] access token expires at %s, renewing it", expiry.Format(time.RFC3339))
+	return t.renew(token)
+}
+
+// renew exchanges the credentials for a new access token unless another request
+// has already replaced the stale token in the meantime
+func (t *bearerTokenTransport) renew(stale string) (string, error) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if t.token != stale {
+		return t.token, nil
+	}
+
+	token, err := t.refresh()
+	if err != nil {
+		log.Printf("[ERROR] unable to renew the access token: %v", err)
+		return "", err
+	}
+
+	t.token = token
+	t.expiry = tokenExpiry(token)
+	return token, nil
+}
+
+// withBearerToken returns a shallow copy of req carrying the given bearer token
+func withBearerToken(req *http.Request, token string) *http.Request {
+	r := new(http.Request)
+	*r = *req
+	r.Header = make(http.Header, len(req.Header))
+	for k, v := range req.Header {
+		r.Header[k] = v
+	}
+	r.Header.Set("Authorization", "Bearer "+token)
+	return r
+}
+
+// tokenExpiry reads the "exp" claim of a JWT access token, it returns the zero
+// time when the token cannot be decoded
+func tokenExpiry(token string) time.Time {
+	parts := strings.Split(token, ".")
+	if len(parts) != 3 {
+		return time.Time{}
+	}
+
+	payload, err := base64.RawURLEncoding.DecodeString(strings.TrimRight(parts[1], "="))
+	if err != nil {
+		return time.Time{}
+	}
+
+	var claims struct {
+		Exp int64 `json:"exp"`
+	}
+	if err := json.Unmarshal(payload, &claims); err != nil || claims.Exp == 0 {
+		return time.Time{}
+	}
+
+	return time.Unix(claims.Exp, 0)
+}
@@ -0,0 +1,93 @@
+package vra
+
+import (
+	"encoding/base64"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+func testJWT(exp time.Time) string {
+	payload := base64.RawURLEncoding.EncodeToString([]byte(fmt.Sprintf(`{"exp":%d}`, exp.Unix())))
+	return "header." + payload + ".signature"
+}
+
+func TestBearerTokenTransport_retriesOnUnauthorized(t *testing.T) {
+	var bodies []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		b, _ := ioutil.ReadAll(r.Body)
+		bodies = append(bodies, string(b))
+		if r.Header.Get("Authorization") != "Bearer renewed" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	refreshes := 0
+	transport := newBearerTokenTransport(http.DefaultTransport, "stale", func() (string, error) {
+		refreshes++
+		return "renewed", nil
+	})
+
+	req, _ := http.NewRequest("POST", server.URL, strings.NewReader(`{"name":"machine"}`))
+	resp, err := transport.RoundTrip(req)
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		t.Fatalf("expected status 200, got %d", resp.StatusCode)
+	}
+	if refreshes != 1 {
+		t.Fatalf("expected 1 token renewal, got %d", refreshes)
+	}
+	if len(bodies) != 2 || bodies[1] != `{"name":"machine"}` {
+		t.Fatalf("expected the request body to be replayed, got %v", bodies)
+	}
+}
+
+func TestBearerTokenTransport_renewsBeforeExpiry(t *testing.T) {
+	renewed := testJWT(time.Now().Add(time.Hour))
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer "+renewed {
+			t.Errorf("unexpected authorization header %q", r.Header.Get("Authorization"))
+		}
+	}))
+	defer server.Close()
+
+	transport := newBearerTokenTransport(http.DefaultTransport, testJWT(time.Now().Add(time.Minute)), func() (string, error) {
+		return renewed, nil
+	})
+
+	req, _ := http.NewRequest("GET", server.URL, nil)
+	if _, err := transport.RoundTrip(req); err != nil {
+		t.Fatalf("err: %s", err)
+	}
+}
+
+func TestBearerTokenTransport_accessTokenOnly(t *testing.T) {
+	calls := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		w.WriteHeader(http.StatusUnauthorized)
+	}))
+	defer server.Close()
+
+	transport := newBearerTokenTransport(http.DefaultTransport, "token", nil)
+
+	req, _ := http.NewRequest("GET", server.URL, nil)
+	resp, err := transport.RoundTrip(req)
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+
+	if resp.StatusCode != http.StatusUnauthorized || calls != 1 {
+		t.Fatalf("expected a single unauthorized call, got status %d after %d calls", resp.StatusCode, calls)
+	}
+}